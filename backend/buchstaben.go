@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	"buchstaben.go/controller"
@@ -10,33 +11,78 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-const gameFilePath = "../data/games.json"
-const wordListFilePath = "../data/dwds_word_list.json"
+const (
+	gameFilePath    = "../data/games.json"
+	oldGameFilePath = "../data/games-old.json"
+	databasePath    = "../data/games.db"
+	backupDir       = "../data/backups"
+	dataDir         = "../data"
+)
 
 func main() {
-	fileSaver := &persistence.FileDataSaver{
-		GameFilePath:     gameFilePath,
-		WordListFilePath: wordListFilePath,
+	storage := flag.String("storage", "json", "where games are stored, \"json\" or \"sqlite\"")
+	database := flag.String("database", databasePath, "SQLite database of the sqlite storage")
+	backups := flag.String("backup-dir", backupDir, "directory for the backups of the JSON games file")
+	keepBackups := flag.Int("backups", 10, "number of backups to keep, 0 disables them")
+	migrate := flag.Bool("migrate", false, "copy the JSON games files into the SQLite database and exit")
+	flag.Parse()
+
+	if *migrate {
+		sqliteSaver := &persistence.SQLiteDataSaver{DatabasePath: *database}
+		defer sqliteSaver.Close()
+		migrated, err := persistence.MigrateToSQLite(sqliteSaver, oldGameFilePath, gameFilePath)
+		if err != nil {
+			fmt.Println("Error migrating games:", err)
+			return
+		}
+		fmt.Printf("Migrated %d active and %d ended games to %s\n", len(migrated.Games), len(migrated.EndedGames), *database)
+		return
+	}
+
+	var saver persistence.DataSaver
+	switch *storage {
+	case "json":
+		saver = &persistence.FileDataSaver{
+			GameFilePath: gameFilePath,
+			WordLists:    persistence.WordLists(dataDir),
+			BackupDir:    *backups,
+			Backups:      *keepBackups,
+		}
+	case "sqlite":
+		sqliteSaver := &persistence.SQLiteDataSaver{
+			DatabasePath: *database,
+			WordLists:    persistence.WordLists(dataDir),
+		}
+		defer sqliteSaver.Close()
+		saver = sqliteSaver
+	default:
+		fmt.Println("Unknown storage:", *storage)
+		return
 	}
-	dataService := service.DataService{Saver: fileSaver}
+	dataService := service.DataService{Saver: saver}
 	dataController := controller.DataController{Service: &dataService}
 
-	if err := fileSaver.LoadGamesFromFile(); err != nil {
+	if err := saver.LoadGamesFromFile(); err != nil {
 		fmt.Println("Error loading games from file:", err)
 		return
 	}
 
-	if err := fileSaver.LoadWordListFromFile(); err != nil {
+	if err := saver.LoadWordListFromFile(); err != nil {
 		fmt.Println("Error loading word list from file:", err)
 		return
 	}
+
+	if err := dataService.IndexDictionaries(); err != nil {
+		fmt.Println("Error indexing word lists:", err)
+		return
+	}
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
 
 	// Configure CORS
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowMethods = []string{"GET", "POST", "DELETE", "OPTIONS"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 	config.AllowHeaders = []string{"Content-Type"}
 	r.Use(cors.New(config))
 
@@ -45,15 +91,58 @@ func main() {
 	r.GET("/games/:username", dataController.GetGameHandler)
 	r.POST("/games/:username", dataController.CreateGameHandler)
 	r.POST("/games/:username/play-move", dataController.PlayMoveHandler)
+	r.PUT("/games/:username/rack", dataController.SetRackHandler)
+	r.PUT("/games/:username/moves/:index", dataController.EditMoveHandler)
+	r.DELETE("/games/:username/moves/:index", dataController.DeleteMoveHandler)
+	r.POST("/games/:username/undo", dataController.UndoHandler)
+	r.GET("/games/:username/events", dataController.GameEventsHandler)
+	r.GET("/games/:username/events/replay", dataController.ReplayEventsHandler)
+	r.GET("/games/:username/replay", dataController.ReplayHandler)
+	r.GET("/games/:username/export.gcg", dataController.ExportGCGHandler)
+	r.GET("/games/:username/best-moves", dataController.BestMovesHandler)
+	r.GET("/games/:username/probabilities", dataController.ProbabilitiesHandler)
+	r.GET("/games/:username/opponent-rack", dataController.OpponentRackHandler)
+	r.GET("/games/:username/endgame", dataController.EndgameHandler)
 	r.GET("/games/end-game", dataController.ListEndedGamesHandler)
 	r.POST("/games/:username/end", dataController.EndGameHandler)
 
+	// the username routes above work while one game against the user is
+	// active, these address every game by its id. They have a prefix of
+	// their own, so no id or route name is taken for a username.
+	r.GET("/game-ids/:gameId", dataController.GetGameByIDHandler)
+	r.POST("/game-ids/:gameId/play-move", dataController.PlayMoveHandler)
+	r.PUT("/game-ids/:gameId/rack", dataController.SetRackHandler)
+	r.PUT("/game-ids/:gameId/moves/:index", dataController.EditMoveHandler)
+	r.DELETE("/game-ids/:gameId/moves/:index", dataController.DeleteMoveHandler)
+	r.POST("/game-ids/:gameId/undo", dataController.UndoHandler)
+	r.GET("/game-ids/:gameId/events", dataController.GameEventsHandler)
+	r.GET("/game-ids/:gameId/events/replay", dataController.ReplayEventsHandler)
+	r.GET("/game-ids/:gameId/replay", dataController.ReplayHandler)
+	r.GET("/game-ids/:gameId/export.gcg", dataController.ExportGCGHandler)
+	r.GET("/game-ids/:gameId/best-moves", dataController.BestMovesHandler)
+	r.GET("/game-ids/:gameId/probabilities", dataController.ProbabilitiesHandler)
+	r.GET("/game-ids/:gameId/opponent-rack", dataController.OpponentRackHandler)
+	r.GET("/game-ids/:gameId/endgame", dataController.EndgameHandler)
+	r.POST("/game-ids/:gameId/end", dataController.EndGameHandler)
+	r.GET("/ended-games/:gameId/replay", dataController.ReplayEndedGameHandler)
+	r.GET("/ended-games/:gameId/export.gcg", dataController.ExportEndedGameGCGHandler)
+	r.POST("/imports/gcg", dataController.ImportGCGHandler)
+
+	r.GET("/stats/opponents/:username", dataController.OpponentStatsHandler)
+
 	r.GET("/played-words", dataController.PlayedWordsHandler)
 	r.GET("/find-words", dataController.FindWordsHandler)
+	r.GET("/pattern-words", dataController.PatternWordsHandler)
 
 	r.GET("/custom-words", dataController.GetCustomWordsHandler)
 	r.POST("/custom-words", dataController.AddCustomWordHandler)
 	r.DELETE("/custom-words/:word", dataController.DeleteCustomWordHandler)
+	r.GET("/custom-words/categories/:category", dataController.GetCategoryWordsHandler)
+	r.POST("/custom-words/categories/:category/import", dataController.ImportCustomWordsHandler)
+	r.GET("/custom-words/categories/:category/export", dataController.ExportCustomWordsHandler)
+
+	r.GET("/admin/backups", dataController.ListBackupsHandler)
+	r.POST("/admin/restore", dataController.RestoreBackupHandler)
 
 	fmt.Println("Starting server on :8080")
 	err := r.Run(":8080")
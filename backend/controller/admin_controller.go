@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (dc *DataController) ListBackupsHandler(c *gin.Context) {
+	backups, err := dc.Service.ListBackups()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, backups)
+}
+
+func (dc *DataController) RestoreBackupHandler(c *gin.Context) {
+	backup := c.Query("backup")
+	if backup == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "backup is required"})
+		return
+	}
+
+	if err := dc.Service.RestoreBackup(backup); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Backup '%s' restored successfully.", backup)})
+}
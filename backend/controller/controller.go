@@ -1,11 +1,15 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"buchstaben.go/logic"
 	"buchstaben.go/model"
 	"buchstaben.go/service"
 )
@@ -25,11 +29,37 @@ func (dc *DataController) CreateGameHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
 		return
 	}
-	if err := dc.Service.CreateGame(username); err != nil {
+	language := c.DefaultQuery("language", logic.DefaultLanguage)
+	userGame, err := dc.Service.CreateGame(username, language)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.Status(http.StatusCreated)
+	c.JSON(http.StatusCreated, userGame)
+}
+
+// gameID returns the id of the game of the request, given in the path or
+// found as the only active game against the username of the path. Unknown
+// games are answered with the status of the handler.
+func (dc *DataController) gameID(c *gin.Context, notFound int) (string, bool) {
+	if gameID := c.Param("gameId"); gameID != "" {
+		return gameID, true
+	}
+	username := c.Param("username")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+		return "", false
+	}
+	gameID, err := dc.Service.GameID(username)
+	if errors.Is(err, service.ErrAmbiguousGame) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return "", false
+	}
+	if err != nil {
+		c.JSON(notFound, gin.H{"error": err.Error()})
+		return "", false
+	}
+	return gameID, true
 }
 
 func (dc *DataController) GetGameHandler(c *gin.Context) {
@@ -39,6 +69,10 @@ func (dc *DataController) GetGameHandler(c *gin.Context) {
 		return
 	}
 	userGame, err := dc.Service.GetLetters(username)
+	if errors.Is(err, service.ErrAmbiguousGame) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -46,10 +80,45 @@ func (dc *DataController) GetGameHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, userGame)
 }
 
+func (dc *DataController) GetGameByIDHandler(c *gin.Context) {
+	userGame, err := dc.Service.GetGame(c.Param("gameId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+type rackRequest struct {
+	Rack string `json:"rack"`
+}
+
+func (dc *DataController) SetRackHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+
+	var request rackRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	userGame, err := dc.Service.SetRack(gameID, request.Rack)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+// PlayMoveHandler plays the move of the body. The points of a move with a
+// placement are computed on the server, the points of a move without one
+// are stored unverified.
 func (dc *DataController) PlayMoveHandler(c *gin.Context) {
-	username := c.Param("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
 		return
 	}
 
@@ -59,7 +128,7 @@ func (dc *DataController) PlayMoveHandler(c *gin.Context) {
 		return
 	}
 
-	updatedGame, err := dc.Service.PlayMove(username, playedMove)
+	updatedGame, err := dc.Service.PlayMove(gameID, playedMove)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -68,17 +137,267 @@ func (dc *DataController) PlayMoveHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, updatedGame)
 }
 
-func (dc *DataController) EndGameHandler(c *gin.Context) {
-	username := c.Param("username")
-	if username == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+// moveIndex reads the index of a played move, counting from 0.
+func moveIndex(c *gin.Context) (int, bool) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "index must be a positive number"})
+		return 0, false
+	}
+	return index, true
+}
+
+func (dc *DataController) DeleteMoveHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	index, ok := moveIndex(c)
+	if !ok {
+		return
+	}
+
+	userGame, err := dc.Service.DeleteMove(gameID, index)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+func (dc *DataController) EditMoveHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	index, ok := moveIndex(c)
+	if !ok {
+		return
+	}
+
+	var playedMove model.PlayedMove
+	if err := c.BindJSON(&playedMove); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	userGame, err := dc.Service.EditMove(gameID, index, playedMove)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+func (dc *DataController) UndoHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	userGame, err := dc.Service.Undo(gameID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+func (dc *DataController) GameEventsHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusNotFound)
+	if !ok {
+		return
+	}
+	events, err := dc.Service.GameEvents(gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	if err := dc.Service.EndGame(username); err != nil {
+	c.JSON(http.StatusOK, events)
+}
+
+// ReplayEventsHandler returns the game as it was after the first events,
+// given by the until query, all events without it.
+func (dc *DataController) ReplayEventsHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	until, err := strconv.Atoi(c.DefaultQuery("until", "-1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be a number"})
+		return
+	}
+
+	userGame, err := dc.Service.ReplayEvents(gameID, until)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, userGame)
+}
+
+// replayMove reads the number of moves to replay, all of them without the
+// move query.
+func replayMove(c *gin.Context) (int, bool) {
+	value, exists := c.GetQuery("move")
+	if !exists {
+		return -1, true
+	}
+	move, err := strconv.Atoi(value)
+	if err != nil || move < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "move must be 0 or a positive number"})
+		return 0, false
+	}
+	return move, true
+}
+
+// gameError responds 404 for an unknown game and 400 for any other error,
+// like a move out of range or an invalid rack.
+func gameError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrGameNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Game for user '%s' ended successfully.", username)})
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+// ReplayHandler returns the game as it was after the move given by the move
+// query, counting from 1.
+func (dc *DataController) ReplayHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusNotFound)
+	if !ok {
+		return
+	}
+	move, ok := replayMove(c)
+	if !ok {
+		return
+	}
+
+	replay, err := dc.Service.ReplayMoves(gameID, move)
+	if err != nil {
+		gameError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, replay)
+}
+
+// ReplayEndedGameHandler returns the ended game as it was after the move
+// given by the move query.
+func (dc *DataController) ReplayEndedGameHandler(c *gin.Context) {
+	move, ok := replayMove(c)
+	if !ok {
+		return
+	}
+
+	replay, err := dc.Service.ReplayEndedGame(c.Param("gameId"), move)
+	if err != nil {
+		gameError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, replay)
+}
+
+func (dc *DataController) BestMovesHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	rack := c.Query("rack")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+		return
+	}
+
+	moves, err := dc.Service.BestMoves(gameID, rack, limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, moves)
+}
+
+func (dc *DataController) ProbabilitiesHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	draws, err := strconv.Atoi(c.DefaultQuery("draws", strconv.Itoa(logic.RackSize)))
+	if err != nil || draws < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "draws must be a positive number"})
+		return
+	}
+
+	odds, err := dc.Service.DrawProbabilities(gameID, c.Query("rack"), draws)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, odds)
+}
+
+func (dc *DataController) OpponentRackHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+
+	opponent, err := dc.Service.OpponentRack(gameID, c.Query("rack"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, opponent)
+}
+
+// maxEndgameBudget limits the time an endgame search may take.
+const maxEndgameBudget = 30 * time.Second
+
+func (dc *DataController) EndgameHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+	budgetMs, err := strconv.Atoi(c.DefaultQuery("budget_ms", "2000"))
+	if err != nil || budgetMs < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "budget_ms must be a positive number"})
+		return
+	}
+	budget := time.Duration(budgetMs) * time.Millisecond
+	if budget > maxEndgameBudget {
+		budget = maxEndgameBudget
+	}
+
+	solution, err := dc.Service.Endgame(gameID, c.Query("rack"), budget)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, solution)
+}
+
+func (dc *DataController) EndGameHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusNotFound)
+	if !ok {
+		return
+	}
+	playedOut := false
+	if value := c.Query("played_out"); value != "" {
+		var err error
+		if playedOut, err = strconv.ParseBool(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "played_out must be true or false"})
+			return
+		}
+	}
+	game, err := dc.Service.EndGame(gameID, c.Query("rack"), playedOut)
+	if err != nil {
+		gameError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": fmt.Sprintf("Game for user '%s' ended successfully.", game.User),
+		"result":  game.Result,
+	})
 }
 
 func (dc *DataController) ListEndedGamesHandler(c *gin.Context) {
@@ -93,16 +412,91 @@ func (dc *DataController) PlayedWordsHandler(c *gin.Context) {
 		c.JSON(http.StatusOK, empty)
 		return
 	}
-	wordsCount := dc.Service.GetPlayedWords(filter)
+	wordsCount, err := dc.Service.GetPlayedWords(filter, c.Query("language"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, wordsCount)
 }
 
+// gameDefaults returns the rack and language of the game given by the
+// game_id or username query, to be used for the parameters not set.
+func (dc *DataController) gameDefaults(c *gin.Context, letters, language string) (string, string, bool) {
+	gameID := c.Query("game_id")
+	if username := c.Query("username"); gameID == "" && username != "" {
+		var err error
+		if gameID, err = dc.Service.GameID(username); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return "", "", false
+		}
+	}
+	if gameID == "" {
+		return letters, language, true
+	}
+	game, err := dc.Service.GetGame(gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return "", "", false
+	}
+	if letters == "" {
+		letters = game.Rack
+	}
+	if language == "" {
+		language = game.Language
+	}
+	return letters, language, true
+}
+
 func (dc *DataController) FindWordsHandler(c *gin.Context) {
-	letters := c.Query("letters")
+	letters, language, ok := dc.gameDefaults(c, c.Query("letters"), c.Query("language"))
+	if !ok {
+		return
+	}
 	if letters == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "letters is required"})
 		return
 	}
-	wordsCount := dc.Service.FindWords(letters)
+	language = logic.LanguageOrDefault(language)
+	category := c.Query("category")
+	wordsCount, err := dc.Service.FindWords(letters, language, category)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, wordsCount)
+}
+
+func (dc *DataController) PatternWordsHandler(c *gin.Context) {
+	letters, language, ok := dc.gameDefaults(c, c.Query("letters"), c.Query("language"))
+	if !ok {
+		return
+	}
+	query := model.PatternQuery{
+		Pattern:  c.Query("pattern"),
+		Letters:  letters,
+		Language: logic.LanguageOrDefault(language),
+		Category: c.Query("category"),
+		Anchor:   c.DefaultQuery("anchor", service.AnchorBoth),
+	}
+	if query.Pattern == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pattern is required"})
+		return
+	}
+	var err error
+	if query.MinLength, err = strconv.Atoi(c.DefaultQuery("min_length", "0")); err != nil || query.MinLength < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_length must be a positive number"})
+		return
+	}
+	if query.MaxLength, err = strconv.Atoi(c.DefaultQuery("max_length", "0")); err != nil || query.MaxLength < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "max_length must be a positive number"})
+		return
+	}
+
+	wordsCount, err := dc.Service.FindPatternWords(query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	c.JSON(http.StatusOK, wordsCount)
 }
@@ -42,6 +42,8 @@ func setupTestEnvironment(t *testing.T) (*DataController, *gin.Engine, string) {
 
 	// Initialize the global persistence
 	model.GlobalPersistence = testData
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {}, "en": {}, "nl": {}}
+	model.GlobalMissingDictionaries = nil
 
 	// Create controller with actual service
 	fileSaver := &persistence.FileDataSaver{GameFilePath: tempFilePath}
@@ -61,9 +63,36 @@ func setupRoutes(router *gin.Engine, controller *DataController) {
 	router.POST("/games/:username", controller.CreateGameHandler)
 	router.GET("/games/:username", controller.GetGameHandler)
 	router.POST("/games/:username/play-move", controller.PlayMoveHandler)
+	router.PUT("/games/:username/rack", controller.SetRackHandler)
+	router.PUT("/games/:username/moves/:index", controller.EditMoveHandler)
+	router.DELETE("/games/:username/moves/:index", controller.DeleteMoveHandler)
+	router.POST("/games/:username/undo", controller.UndoHandler)
+	router.GET("/games/:username/events", controller.GameEventsHandler)
+	router.GET("/games/:username/events/replay", controller.ReplayEventsHandler)
+	router.GET("/games/:username/replay", controller.ReplayHandler)
+	router.GET("/games/:username/export.gcg", controller.ExportGCGHandler)
+	router.GET("/games/:username/best-moves", controller.BestMovesHandler)
+	router.GET("/games/:username/probabilities", controller.ProbabilitiesHandler)
+	router.GET("/games/:username/opponent-rack", controller.OpponentRackHandler)
+	router.GET("/games/:username/endgame", controller.EndgameHandler)
 	router.POST("/games/:username/end-game", controller.EndGameHandler)
 	router.GET("/games/end-game", controller.ListEndedGamesHandler)
+	router.GET("/ended-games/:gameId/replay", controller.ReplayEndedGameHandler)
+	router.POST("/imports/gcg", controller.ImportGCGHandler)
+	router.GET("/game-ids/:gameId", controller.GetGameByIDHandler)
+	router.POST("/game-ids/:gameId/play-move", controller.PlayMoveHandler)
+	router.PUT("/game-ids/:gameId/rack", controller.SetRackHandler)
+	router.GET("/game-ids/:gameId/replay", controller.ReplayHandler)
+	router.POST("/game-ids/:gameId/end", controller.EndGameHandler)
+	router.GET("/stats/opponents/:username", controller.OpponentStatsHandler)
 	router.GET("/played-words", controller.PlayedWordsHandler)
+	router.GET("/find-words", controller.FindWordsHandler)
+	router.GET("/admin/backups", controller.ListBackupsHandler)
+	router.POST("/admin/restore", controller.RestoreBackupHandler)
+	router.GET("/pattern-words", controller.PatternWordsHandler)
+	router.GET("/custom-words/categories/:category", controller.GetCategoryWordsHandler)
+	router.POST("/custom-words/categories/:category/import", controller.ImportCustomWordsHandler)
+	router.GET("/custom-words/categories/:category/export", controller.ExportCustomWordsHandler)
 }
 
 // cleanupTestEnvironment removes temporary files
@@ -103,17 +132,28 @@ func TestCreateGameHandler(t *testing.T) {
 	// Assertions for successful creation
 	assert.Equal(t, http.StatusCreated, w.Code)
 
-	// Test duplicate creation
+	// Test a second game against the same user
 	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, model.GlobalPersistence.Games, 2, "Expected two games against the same user")
 
-	// Assertions for duplicate creation
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var errorResponse map[string]string
-	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	// Test creating a game with a language
+	req = httptest.NewRequest(http.MethodPost, "/games/dutchuser?language=nl", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created model.UserGame
+	err := json.Unmarshal(w.Body.Bytes(), &created)
 	assert.NoError(t, err)
-	assert.Contains(t, errorResponse["error"], "already exists")
+	assert.Equal(t, "nl", model.GlobalPersistence.Games[created.ID].Language)
+
+	// Test creating a game with an unsupported language
+	req = httptest.NewRequest(http.MethodPost, "/games/otheruser?language=xx", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestListGamesHandler_WithGames(t *testing.T) {
@@ -236,11 +276,42 @@ func TestEndGameHandler(t *testing.T) {
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response map[string]string
+	var response struct {
+		Message string           `json:"message"`
+		Result  model.GameResult `json:"result"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Contains(t, response["message"], "testuser")
-	assert.Contains(t, response["message"], "ended successfully")
+	assert.Contains(t, response.Message, "testuser")
+	assert.Contains(t, response.Message, "ended successfully")
+	assert.Equal(t, model.OutcomeDraw, response.Result.Outcome, "Expected a draw without moves")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/end-game?played_out=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected 400 for an invalid played_out")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/end-game?played_out=true&rack=ab", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected 400 for a rack after playing out")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/end-game?rack=abcdefgh", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected 400 for a rack with too many letters")
+
+	req = httptest.NewRequest(http.MethodPost, "/game-ids/unknown/end", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for an unknown game id")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/end-game?played_out=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
 }
 
 func TestListEndedGamesHandler(t *testing.T) {
@@ -272,6 +343,10 @@ func TestListEndedGamesHandler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, response, 1, "Expected one ended game")
 	assert.Equal(t, "testuser", response[0].User)
+	assert.NotEmpty(t, response[0].GameEndTimestamp)
+	if assert.NotNil(t, response[0].Result, "Expected the result of the ended game") {
+		assert.Equal(t, model.OutcomeDraw, response[0].Result.Outcome)
+	}
 }
 
 func TestPlayedWordsHandler(t *testing.T) {
@@ -317,10 +392,17 @@ func TestPlayedWordsHandler(t *testing.T) {
 	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	// Check played words
+	// Without a filter no words are returned
 	req = httptest.NewRequest(http.MethodGet, "/played-words", nil)
 	w = httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+
+	// Check played words
+	req = httptest.NewRequest(http.MethodGet, "/played-words?filter=helloworld", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
 
 	// Assertions
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -415,3 +497,628 @@ func TestPlayMoveHandler_InvalidLetter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, response["error"], "not valid")
 }
+
+func TestBestMovesHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"hut": "", "tut": "", "ah": ""}}
+
+	// Create a game first
+	req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Missing rack
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/best-moves", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Invalid limit
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/best-moves?rack=hut&limit=x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Find the best moves
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/best-moves?rack=hut&limit=2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var moves []map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &moves)
+	assert.NoError(t, err)
+	assert.Len(t, moves, 2, "Expected moves to be limited")
+	assert.Equal(t, "hut", moves[0]["letters"])
+	assert.Equal(t, float64(4), moves[0]["points"])
+
+	// Unknown game
+	req = httptest.NewRequest(http.MethodGet, "/games/unknown/best-moves?rack=hut", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProbabilitiesHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Invalid draws
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/probabilities?draws=0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Invalid rack
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/probabilities?rack=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/probabilities?rack=hut", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var odds map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &odds)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), odds["draws"], "Expected a full rack to be drawn by default")
+	assert.Greater(t, odds["blank"], float64(0))
+}
+
+func TestOpponentRackHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/testuser/opponent-rack", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected error for non-existent game")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/opponent-rack", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected error without a rack")
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/opponent-rack?rack=hut", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var opponent map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &opponent)
+	assert.NoError(t, err)
+	assert.Equal(t, false, opponent["exact"])
+	assert.NotEmpty(t, opponent["letters"])
+}
+
+func TestSetRackHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"hut": "", "tut": "", "ah": ""}}
+
+	req := httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString(`{"rack": "hut"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected error for non-existent game")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Invalid body
+	req = httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString("{"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Tiles already gone
+	req = httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString(`{"rack": "qq"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString(`{"rack": "HUT"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var game model.UserGame
+	err := json.Unmarshal(w.Body.Bytes(), &game)
+	assert.NoError(t, err)
+	assert.Equal(t, "hut", game.Rack)
+
+	// The stored rack is the default of the game endpoints
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/best-moves", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/find-words?username=testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var words []model.WordCount
+	err = json.Unmarshal(w.Body.Bytes(), &words)
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "hut", words[0].Word)
+
+	req = httptest.NewRequest(http.MethodGet, "/find-words?username=unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEndgameHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Invalid budget
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/endgame?rack=hut&budget_ms=x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Bag not empty yet
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/endgame?rack=hut", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]string
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Contains(t, response["error"], "bag is not empty")
+}
+
+func TestMoveHandlers(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/play-move", bytes.NewBufferString(`{"letters": "xyz"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Invalid index
+	req = httptest.NewRequest(http.MethodDelete, "/games/testuser/moves/x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Edit the typo
+	req = httptest.NewRequest(http.MethodPut, "/games/testuser/moves/0", bytes.NewBufferString(`{"letters": "xy"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var game model.UserGame
+	err := json.Unmarshal(w.Body.Bytes(), &game)
+	assert.NoError(t, err)
+	assert.Equal(t, "xy", game.PlayedMoves[0].Letters)
+
+	// Delete the move
+	req = httptest.NewRequest(http.MethodDelete, "/games/testuser/moves/0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &game)
+	assert.NoError(t, err)
+	assert.Empty(t, game.PlayedMoves)
+
+	req = httptest.NewRequest(http.MethodDelete, "/games/testuser/moves/0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected error for move out of range")
+}
+
+func TestGameEventHandlers(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/testuser/events", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/play-move", bytes.NewBufferString(`{"letters": "xyz"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/events", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var events []model.GameEvent
+	err := json.Unmarshal(w.Body.Bytes(), &events)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// Replay up to the creation
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/events/replay?until=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var game model.UserGame
+	err = json.Unmarshal(w.Body.Bytes(), &game)
+	assert.NoError(t, err)
+	assert.Empty(t, game.PlayedMoves)
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/events/replay?until=x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Undo
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/undo", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &game)
+	assert.NoError(t, err)
+	assert.Empty(t, game.PlayedMoves)
+	assert.Len(t, game.Events, 3)
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/undo", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRestoreBackupHandler(t *testing.T) {
+	controller, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+	controller.Service.Saver = &persistence.FileDataSaver{
+		GameFilePath: tempFile,
+		BackupDir:    t.TempDir(),
+		Backups:      5,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/backups", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var backups []string
+	err := json.Unmarshal(w.Body.Bytes(), &backups)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+
+	// Missing backup
+	req = httptest.NewRequest(http.MethodPost, "/admin/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/restore?backup=unknown.json", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Roll back to the games before the game was created
+	req = httptest.NewRequest(http.MethodPost, "/admin/restore?backup="+backups[0], nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, model.GlobalPersistence.Games)
+}
+
+func TestPatternWordsHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"haus": "", "maus": "", "hausen": ""}}
+
+	// Missing pattern
+	req := httptest.NewRequest(http.MethodGet, "/pattern-words?letters=hm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Invalid length
+	req = httptest.NewRequest(http.MethodGet, "/pattern-words?pattern=_aus&letters=hm&max_length=x", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Invalid anchor
+	req = httptest.NewRequest(http.MethodGet, "/pattern-words?pattern=_aus&letters=hm&anchor=middle", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Find words
+	req = httptest.NewRequest(http.MethodGet, "/pattern-words?pattern=_aus&letters=hen&anchor=start&min_length=5", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var words []model.WordCount
+	err := json.Unmarshal(w.Body.Bytes(), &words)
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "hausen", words[0].Word)
+}
+
+func TestCustomWordsCategoryHandlers(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	// Import plain text
+	req := httptest.NewRequest(http.MethodPost, "/custom-words/categories/invalid/import", bytes.NewBufferString("ob\n# comment\n\nab\n"))
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var result model.ImportResult
+	err := json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportResult{Imported: 2}, result)
+
+	// Import JSON
+	req = httptest.NewRequest(http.MethodPost, "/custom-words/categories/allowed/import", bytes.NewBufferString(`{"words": ["ab", "bo"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportResult{Imported: 1, Updated: 1}, result)
+
+	// Invalid body
+	req = httptest.NewRequest(http.MethodPost, "/custom-words/categories/allowed/import", bytes.NewBufferString("{"))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// List
+	req = httptest.NewRequest(http.MethodGet, "/custom-words/categories/allowed", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var customWords []model.CustomWord
+	err = json.Unmarshal(w.Body.Bytes(), &customWords)
+	assert.NoError(t, err)
+	assert.Len(t, customWords, 2)
+
+	// Export
+	req = httptest.NewRequest(http.MethodGet, "/custom-words/categories/invalid/export", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ob\n", w.Body.String())
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "invalid.txt")
+}
+
+func TestOpponentStatsHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/stats/opponents/testuser", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected not found without games")
+
+	model.GlobalPersistence.EndedGames = append(model.GlobalPersistence.EndedGames, model.UserGame{
+		User:               "testuser",
+		GameStartTimestamp: "2024-01-01 10:00:00",
+		GameEndTimestamp:   "2024-01-01 12:00:00",
+		PlayedMoves:        []model.PlayedMove{{Letters: "hut", Points: 12, PlayedByMyself: true}, {Letters: "ab", Points: 5}},
+	})
+
+	req = httptest.NewRequest(http.MethodGet, "/stats/opponents/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var stats model.OpponentStats
+	err := json.Unmarshal(w.Body.Bytes(), &stats)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Wins)
+	assert.Equal(t, 7.0, stats.AverageSpread)
+	assert.Equal(t, int64(7200), stats.AverageDuration)
+}
+
+func TestGameIDRoutes(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	gameIDs := []string{}
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var created model.UserGame
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.NotEmpty(t, created.ID)
+		gameIDs = append(gameIDs, created.ID)
+	}
+
+	// the username routes are ambiguous with two games
+	req := httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString(`{"rack": "hut"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/game-ids/"+gameIDs[1]+"/rack", bytes.NewBufferString(`{"rack": "hut"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/game-ids/"+gameIDs[1], nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var game model.UserGame
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &game))
+	assert.Equal(t, "hut", game.Rack)
+	assert.Empty(t, model.GlobalPersistence.Games[gameIDs[0]].Rack, "Expected the other game to be unchanged")
+
+	req = httptest.NewRequest(http.MethodGet, "/game-ids/unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// ending one game makes the username routes work again
+	req = httptest.NewRequest(http.MethodPost, "/game-ids/"+gameIDs[0]+"/end", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/games/testuser/rack", bytes.NewBufferString(`{"rack": "ab"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "ab", model.GlobalPersistence.Games[gameIDs[1]].Rack)
+}
+
+func TestReplayHandler(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/testuser/replay?move=1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var created model.UserGame
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	for _, move := range []string{`{"letters": "hut", "points": 4, "played_by_myself": true}`, `{"letters": "ab", "points": 6}`} {
+		req = httptest.NewRequest(http.MethodPost, "/games/testuser/play-move", bytes.NewBufferString(move))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/replay?move=1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var replay model.GameReplay
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &replay))
+	assert.Equal(t, 1, replay.Move)
+	assert.Equal(t, 2, replay.TotalMoves)
+	assert.Equal(t, 4, replay.MyScore)
+
+	for _, query := range []string{"move=x", "move=-1", "move=3"} {
+		req = httptest.NewRequest(http.MethodGet, "/games/testuser/replay?"+query, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	assert.Contains(t, w.Body.String(), "move 3 not found")
+
+	req = httptest.NewRequest(http.MethodGet, "/games/testuser/replay?move=0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "Expected the empty board for move 0")
+
+	req = httptest.NewRequest(http.MethodGet, "/game-ids/unknown/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for an unknown game id")
+
+	req = httptest.NewRequest(http.MethodPost, "/games/testuser/end-game", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/ended-games/"+created.ID+"/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &replay))
+	assert.Equal(t, 2, replay.Move, "Expected all moves without the move query")
+	assert.Equal(t, 6, replay.OpponentScore)
+
+	req = httptest.NewRequest(http.MethodGet, "/ended-games/"+created.ID+"/replay?move=3", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/ended-games/unknown/replay", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected 404 for an unknown ended game")
+}
+
+func TestGCGHandlers(t *testing.T) {
+	_, router, tempFile := setupTestEnvironment(t)
+	defer cleanupTestEnvironment(t, tempFile)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/testuser/export.gcg", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	gcg := "#player1 anna Anna\n#player2 me me\n>anna: HUT 8G HUT +8 8\n>me: ?AB G8 .Ab +3 3\n"
+	req = httptest.NewRequest(http.MethodPost, "/imports/gcg?me=me", bytes.NewBufferString(gcg))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var game model.UserGame
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &game))
+	assert.Equal(t, "Anna", game.User)
+	assert.Len(t, game.PlayedMoves, 2)
+
+	req = httptest.NewRequest(http.MethodGet, "/games/Anna/export.gcg", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".gcg")
+	assert.Contains(t, w.Body.String(), ">Anna: HUT 8G HUT +8 8\n>me: A? G8 .Ab +3 3\n")
+
+	req = httptest.NewRequest(http.MethodPost, "/imports/gcg", bytes.NewBufferString(">me: 8G HUT +8 8\n"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/imports/gcg", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
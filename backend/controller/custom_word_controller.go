@@ -2,7 +2,9 @@ package controller
 
 import (
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"buchstaben.go/model"
 	"github.com/gin-gonic/gin"
@@ -28,6 +30,8 @@ func (dc *DataController) AddCustomWordHandler(c *gin.Context) {
 	c.Status(http.StatusCreated)
 }
 
+// DeleteCustomWordHandler removes the word of the language query, the
+// default language without it.
 func (dc *DataController) DeleteCustomWordHandler(c *gin.Context) {
 	word := c.Param("word")
 	if word == "" {
@@ -35,10 +39,58 @@ func (dc *DataController) DeleteCustomWordHandler(c *gin.Context) {
 		return
 	}
 
-	if err := dc.Service.DeleteCustomWord(word); err != nil {
+	if err := dc.Service.DeleteCustomWord(word, c.Query("language")); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Word '%s' deleted successfully.", word)})
 }
+
+func (dc *DataController) GetCategoryWordsHandler(c *gin.Context) {
+	customWords := dc.Service.GetCustomWordsByCategory(c.Param("category"), c.Query("language"))
+	c.JSON(http.StatusOK, customWords)
+}
+
+// ImportCustomWordsHandler imports a JSON body of custom words or a plain
+// text body with one word per line into the category.
+func (dc *DataController) ImportCustomWordsHandler(c *gin.Context) {
+	var newWords model.CustomWords
+	if c.ContentType() == "text/plain" {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		for _, line := range strings.Split(string(body), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				newWords.Words = append(newWords.Words, line)
+			}
+		}
+		newWords.Language = c.Query("language")
+	} else if err := c.BindJSON(&newWords); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	newWords.Category = c.Param("category")
+
+	result, err := dc.Service.ImportCustomWords(newWords)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportCustomWordsHandler returns the words of the category as plain text
+// with one word per line, the format ImportCustomWordsHandler reads.
+func (dc *DataController) ExportCustomWordsHandler(c *gin.Context) {
+	category := c.Param("category")
+	var export strings.Builder
+	for _, customWord := range dc.Service.GetCustomWordsByCategory(category, c.Query("language")) {
+		export.WriteString(customWord.Word)
+		export.WriteString("\n")
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", category+".txt"))
+	c.String(http.StatusOK, export.String())
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"buchstaben.go/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportGCGHandler returns the active game as a GCG file. Moves played
+// without a position are only notes in the file, with their points and the
+// running total, analysis tools skip them.
+func (dc *DataController) ExportGCGHandler(c *gin.Context) {
+	gameID, ok := dc.gameID(c, http.StatusNotFound)
+	if !ok {
+		return
+	}
+
+	game, gcg, err := dc.Service.ExportGCG(gameID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	sendGCG(c, game, gcg)
+}
+
+// ExportEndedGameGCGHandler returns the ended game as a GCG file, with the
+// same notes for moves without a position as ExportGCGHandler.
+func (dc *DataController) ExportEndedGameGCGHandler(c *gin.Context) {
+	game, gcg, err := dc.Service.ExportEndedGameGCG(c.Param("gameId"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	sendGCG(c, game, gcg)
+}
+
+func sendGCG(c *gin.Context, game model.UserGame, gcg string) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", game.User+"-"+game.ID+".gcg"))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(gcg))
+}
+
+// ImportGCGHandler creates a game out of the GCG file of the body. The me
+// query names my nickname in the file, player1 without it.
+func (dc *DataController) ImportGCGHandler(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(body) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	game, err := dc.Service.ImportGCG(string(body), c.Query("me"), c.Query("language"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, game)
+}
@@ -0,0 +1,193 @@
+package logic
+
+import (
+	"fmt"
+
+	"buchstaben.go/model"
+)
+
+const BoardSize = 15
+
+// Premium is the bonus of a square on the board.
+type Premium int
+
+const (
+	NoPremium Premium = iota
+	DoubleLetter
+	TripleLetter
+	DoubleWord
+	TripleWord
+)
+
+func (p Premium) String() string {
+	switch p {
+	case DoubleLetter:
+		return "DL"
+	case TripleLetter:
+		return "TL"
+	case DoubleWord:
+		return "DW"
+	case TripleWord:
+		return "TW"
+	}
+	return ""
+}
+
+// standardLayout is the default Wordfeud board:
+// l = double letter, L = triple letter, w = double word, W = triple word.
+var standardLayout = [BoardSize]string{
+	"L...W..l..W...L",
+	".l...L...L...l.",
+	"..w...l.l...w..",
+	"...L...w...L...",
+	"W...w.l.l.w...W",
+	".L...L...L...L.",
+	"..l.l.....l.l..",
+	"l..w.......w..l",
+	"..l.l.....l.l..",
+	".L...L...L...L.",
+	"W...w.l.l.w...W",
+	"...L...w...L...",
+	"..w...l.l...w..",
+	".l...L...L...l.",
+	"L...W..l..W...L",
+}
+
+// Layout holds the premium of every square of the board.
+type Layout [BoardSize][BoardSize]Premium
+
+func LoadStandardLayout() Layout {
+	var layout Layout
+	for row, line := range standardLayout {
+		for col, square := range line {
+			switch square {
+			case 'l':
+				layout[row][col] = DoubleLetter
+			case 'L':
+				layout[row][col] = TripleLetter
+			case 'w':
+				layout[row][col] = DoubleWord
+			case 'W':
+				layout[row][col] = TripleWord
+			}
+		}
+	}
+	return layout
+}
+
+// Grid gives positional access to the tiles of a board. Empty squares have
+// an empty Letter.
+type Grid [BoardSize][BoardSize]model.PlacedTile
+
+func NewGrid(board model.Board) Grid {
+	var grid Grid
+	for _, tile := range board {
+		grid[tile.Row][tile.Col] = tile
+	}
+	return grid
+}
+
+func (g *Grid) IsEmpty() bool {
+	for row := range g {
+		for col := range g[row] {
+			if g[row][col].Letter != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (g *Grid) occupied(row, col int) bool {
+	return onBoard(row, col) && g[row][col].Letter != ""
+}
+
+func onBoard(row, col int) bool {
+	return row >= 0 && row < BoardSize && col >= 0 && col < BoardSize
+}
+
+// step returns the row and column increments for a direction.
+func step(direction string) (int, int, error) {
+	switch direction {
+	case model.Horizontal:
+		return 0, 1, nil
+	case model.Vertical:
+		return 1, 0, nil
+	}
+	return 0, 0, fmt.Errorf("direction %q is not valid, use %q or %q", direction, model.Horizontal, model.Vertical)
+}
+
+// NewTiles lays out the letters of a placement on the board without
+// modifying it and returns the newly placed tiles.
+func NewTiles(board model.Board, placement model.Placement, letters string) ([]model.PlacedTile, error) {
+	dRow, dCol, err := step(placement.Direction)
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(letters)
+	if len(runes) == 0 {
+		return nil, fmt.Errorf("no letters to place")
+	}
+	blanks := make(map[int]bool)
+	for _, i := range placement.Blanks {
+		if i < 0 || i >= len(runes) {
+			return nil, fmt.Errorf("blank position %d is out of range", i)
+		}
+		blanks[i] = true
+	}
+
+	grid := NewGrid(board)
+	tiles := make([]model.PlacedTile, 0, len(runes))
+	row, col := placement.Row, placement.Col
+	for i, letter := range runes {
+		for grid.occupied(row, col) {
+			row, col = row+dRow, col+dCol
+		}
+		if !onBoard(row, col) {
+			return nil, fmt.Errorf("letter %q does not fit on the board", string(letter))
+		}
+		tiles = append(tiles, model.PlacedTile{Row: row, Col: col, Letter: string(letter), Blank: blanks[i]})
+		row, col = row+dRow, col+dCol
+	}
+
+	if grid.IsEmpty() {
+		center := BoardSize / 2
+		for _, tile := range tiles {
+			if tile.Row == center && tile.Col == center {
+				return tiles, nil
+			}
+		}
+		return nil, fmt.Errorf("the first move has to cover the center square")
+	}
+	for _, tile := range tiles {
+		if grid.occupied(tile.Row-1, tile.Col) || grid.occupied(tile.Row+1, tile.Col) ||
+			grid.occupied(tile.Row, tile.Col-1) || grid.occupied(tile.Row, tile.Col+1) {
+			return tiles, nil
+		}
+	}
+	return nil, fmt.Errorf("the move is not connected to any tile on the board")
+}
+
+// PlaceTiles puts the letters of a placement on the board and returns the
+// updated board.
+func PlaceTiles(board model.Board, placement model.Placement, letters string) (model.Board, error) {
+	tiles, err := NewTiles(board, placement, letters)
+	if err != nil {
+		return board, err
+	}
+	updated := make(model.Board, 0, len(board)+len(tiles))
+	updated = append(updated, board...)
+	return append(updated, tiles...), nil
+}
+
+// PlacementTiles returns the tiles taken from the letter set for a placement,
+// letters played as blank are replaced by "*".
+func PlacementTiles(letters string, blanks []int) string {
+	runes := []rune(letters)
+	for _, i := range blanks {
+		if i >= 0 && i < len(runes) {
+			runes[i] = '*'
+		}
+	}
+	return string(runes)
+}
@@ -0,0 +1,123 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadStandardLayout(t *testing.T) {
+	layout := LoadStandardLayout()
+
+	assert.Equal(t, TripleLetter, layout[0][0], "Corner should be a triple letter")
+	assert.Equal(t, TripleWord, layout[0][4], "Square 0/4 should be a triple word")
+	assert.Equal(t, DoubleLetter, layout[1][1], "Square 1/1 should be a double letter")
+	assert.Equal(t, DoubleWord, layout[2][2], "Square 2/2 should be a double word")
+	assert.Equal(t, NoPremium, layout[7][7], "Center should have no premium")
+
+	// The layout is symmetric along both axes and the diagonal
+	for row := 0; row < BoardSize; row++ {
+		for col := 0; col < BoardSize; col++ {
+			assert.Equal(t, layout[row][col], layout[BoardSize-1-row][col], "Layout should be symmetric at %d/%d", row, col)
+			assert.Equal(t, layout[row][col], layout[row][BoardSize-1-col], "Layout should be symmetric at %d/%d", row, col)
+			assert.Equal(t, layout[row][col], layout[col][row], "Layout should be symmetric at %d/%d", row, col)
+		}
+	}
+}
+
+func TestPlaceTiles(t *testing.T) {
+	firstWord := model.Board{
+		{Row: 7, Col: 6, Letter: "h"},
+		{Row: 7, Col: 7, Letter: "u"},
+		{Row: 7, Col: 8, Letter: "t"},
+	}
+
+	testCases := []struct {
+		name          string
+		board         model.Board
+		placement     model.Placement
+		letters       string
+		expectedTiles []model.PlacedTile
+		errorContains string
+	}{
+		{
+			name:      "First move across the center",
+			board:     model.Board{},
+			placement: model.Placement{Row: 7, Col: 6, Direction: model.Horizontal},
+			letters:   "hut",
+			expectedTiles: []model.PlacedTile{
+				{Row: 7, Col: 6, Letter: "h"},
+				{Row: 7, Col: 7, Letter: "u"},
+				{Row: 7, Col: 8, Letter: "t"},
+			},
+		},
+		{
+			name:          "First move away from the center",
+			board:         model.Board{},
+			placement:     model.Placement{Row: 0, Col: 0, Direction: model.Horizontal},
+			letters:       "hut",
+			errorContains: "center",
+		},
+		{
+			name:      "Skip occupied squares",
+			board:     firstWord,
+			placement: model.Placement{Row: 6, Col: 7, Direction: model.Vertical, Blanks: []int{1}},
+			letters:   "mt",
+			expectedTiles: []model.PlacedTile{
+				{Row: 6, Col: 7, Letter: "m"},
+				{Row: 8, Col: 7, Letter: "t", Blank: true},
+			},
+		},
+		{
+			name:          "Not connected",
+			board:         firstWord,
+			placement:     model.Placement{Row: 0, Col: 0, Direction: model.Vertical},
+			letters:       "ab",
+			errorContains: "not connected",
+		},
+		{
+			name:          "Off the board",
+			board:         firstWord,
+			placement:     model.Placement{Row: 7, Col: 13, Direction: model.Horizontal},
+			letters:       "abc",
+			errorContains: "does not fit",
+		},
+		{
+			name:          "Invalid direction",
+			board:         firstWord,
+			placement:     model.Placement{Row: 6, Col: 7, Direction: "diagonal"},
+			letters:       "a",
+			errorContains: "direction",
+		},
+		{
+			name:          "Blank out of range",
+			board:         firstWord,
+			placement:     model.Placement{Row: 6, Col: 7, Direction: model.Vertical, Blanks: []int{3}},
+			letters:       "a",
+			errorContains: "blank position",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := PlaceTiles(tc.board, tc.placement, tc.letters)
+
+			if tc.errorContains != "" {
+				assert.Error(t, err, "Should return an error")
+				assert.Contains(t, err.Error(), tc.errorContains, "Error should contain expected text")
+				assert.Equal(t, tc.board, result, "When error occurs, original board should be returned")
+				return
+			}
+			assert.NoError(t, err, "Should not return an error")
+			assert.Len(t, result, len(tc.board)+len(tc.expectedTiles), "Board should contain the new tiles")
+			assert.Equal(t, tc.expectedTiles, []model.PlacedTile(result[len(tc.board):]), "New tiles should be placed as expected")
+		})
+	}
+}
+
+func TestPlacementTiles(t *testing.T) {
+	assert.Equal(t, "h*t", PlacementTiles("hut", []int{1}), "Blank positions should be replaced by '*'")
+	assert.Equal(t, "hut", PlacementTiles("hut", nil), "Letters without blanks should be unchanged")
+	assert.Equal(t, "*ber", PlacementTiles("über", []int{0}), "Blank positions should count runes")
+}
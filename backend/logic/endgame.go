@@ -0,0 +1,219 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"buchstaben.go/model"
+)
+
+// EndgameCandidates is the number of best scoring moves searched per
+// position, moves playing out the whole rack are always searched.
+const EndgameCandidates = 10
+
+// maxEndgameDepth limits the plies searched, it is reached only if both
+// players keep passing and playing single tiles.
+const maxEndgameDepth = 2*RackSize + 2
+
+// EndgamePosition is a game with an empty bag. Player 0 is me, player 1 the
+// opponent.
+type EndgamePosition struct {
+	Board  model.Board
+	Racks  [2]string
+	Scores [2]int
+	ToMove int
+	// Passes counts the passes in a row, two of them end the game
+	Passes int
+}
+
+// EndgamePly is a move or a pass of a player.
+type EndgamePly struct {
+	Player int   `json:"player"`
+	Pass   bool  `json:"pass"`
+	Move   *Move `json:"move,omitempty"`
+}
+
+// EndgameSolution is the best sequence of plies found for both players.
+type EndgameSolution struct {
+	Plies       []EndgamePly `json:"plies"`
+	FinalScores [2]int       `json:"final_scores"`
+	// Spread is my final score minus the opponent's
+	Spread int `json:"spread"`
+	Depth  int `json:"depth"`
+	// Exhaustive is set if every line was searched to the end of the game and
+	// no moves were left out beyond the EndgameCandidates of a position
+	Exhaustive bool `json:"exhaustive"`
+}
+
+type endgameSearch struct {
+	gaddag   *Gaddag
+	layout   Layout
+	values   map[string]uint
+	deadline time.Time
+	// mustFinish disables the deadline for the first iteration
+	mustFinish bool
+	aborted    bool
+	cutoff     bool
+	// truncated is set if candidates left out moves
+	truncated bool
+	err       error
+}
+
+// SolveEndgame searches the position with alpha-beta pruning, deepening the
+// search until every line reaches the end of the game or the budget is used
+// up. The solution of the deepest completed search is returned.
+func SolveEndgame(gaddag *Gaddag, position EndgamePosition, layout Layout, values map[string]uint, budget time.Duration) (EndgameSolution, error) {
+	search := &endgameSearch{
+		gaddag:   gaddag,
+		layout:   layout,
+		values:   values,
+		deadline: time.Now().Add(budget),
+	}
+
+	var best []EndgamePly
+	depth := 0
+	exhaustive := false
+	for d := 1; d <= maxEndgameDepth; d++ {
+		search.mustFinish = d == 1
+		search.aborted = false
+		search.cutoff = false
+		search.truncated = false
+		_, plies := search.negamax(position, d, math.MinInt32, math.MaxInt32)
+		if search.err != nil {
+			return EndgameSolution{}, search.err
+		}
+		if search.aborted {
+			break
+		}
+		best, depth = plies, d
+		if !search.cutoff {
+			exhaustive = !search.truncated
+			break
+		}
+	}
+
+	final := position
+	for _, ply := range best {
+		var err error
+		if final, _, err = search.play(final, ply); err != nil {
+			return EndgameSolution{}, err
+		}
+	}
+	return EndgameSolution{
+		Plies:       best,
+		FinalScores: final.Scores,
+		Spread:      final.Scores[0] - final.Scores[1],
+		Depth:       depth,
+		Exhaustive:  exhaustive,
+	}, nil
+}
+
+// negamax returns the value of the position for the player to move and the
+// principal variation.
+func (s *endgameSearch) negamax(position EndgamePosition, depth, alpha, beta int) (int, []EndgamePly) {
+	if !s.mustFinish && time.Now().After(s.deadline) {
+		s.aborted = true
+		return 0, nil
+	}
+	if depth == 0 {
+		s.cutoff = true
+		return position.spread(), nil
+	}
+
+	best := math.MinInt32
+	var bestLine []EndgamePly
+	for _, ply := range s.candidates(position) {
+		next, ended, err := s.play(position, ply)
+		if err != nil {
+			s.err = err
+			s.aborted = true
+			return 0, nil
+		}
+		var value int
+		var line []EndgamePly
+		if ended {
+			value = -next.spread()
+		} else {
+			value, line = s.negamax(next, depth-1, -beta, -alpha)
+			value = -value
+		}
+		if s.aborted {
+			return 0, nil
+		}
+		if value > best {
+			best = value
+			bestLine = append([]EndgamePly{ply}, line...)
+		}
+		if best > alpha {
+			alpha = best
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestLine
+}
+
+// candidates returns the best scoring moves, the moves playing out and the
+// pass of the player to move.
+func (s *endgameSearch) candidates(position EndgamePosition) []EndgamePly {
+	rack := position.Racks[position.ToMove]
+	moves := GenerateMoves(s.gaddag, position.Board, rack, s.layout, s.values)
+	plies := make([]EndgamePly, 0, EndgameCandidates+1)
+	for i := range moves {
+		if i >= EndgameCandidates && len([]rune(moves[i].Tiles())) < len([]rune(rack)) {
+			s.truncated = true
+			continue
+		}
+		plies = append(plies, EndgamePly{Player: position.ToMove, Move: &moves[i]})
+	}
+	return append(plies, EndgamePly{Player: position.ToMove, Pass: true})
+}
+
+// play returns the position after the ply and reports if it ended the game.
+func (s *endgameSearch) play(position EndgamePosition, ply EndgamePly) (EndgamePosition, bool, error) {
+	next := position
+	player := position.ToMove
+	next.ToMove = 1 - player
+	if ply.Pass {
+		next.Passes++
+		if next.Passes < 2 {
+			return next, false, nil
+		}
+		for i := range next.Scores {
+			next.Scores[i] -= s.rackValue(next.Racks[i])
+		}
+		return next, true, nil
+	}
+
+	board, err := PlaceTiles(position.Board, ply.Move.Placement, ply.Move.Letters)
+	if err != nil {
+		return position, false, fmt.Errorf("failed to play %s: %v", ply.Move.Letters, err)
+	}
+	next.Board = board
+	next.Passes = 0
+	next.Racks[player] = RemoveFromRack(position.Racks[player], ply.Move.Tiles())
+	next.Scores[player] += int(ply.Move.Points)
+	if next.Racks[player] != "" {
+		return next, false, nil
+	}
+	// playing out wins the value of the tiles left on the other rack
+	left := s.rackValue(next.Racks[1-player])
+	next.Scores[player] += left
+	next.Scores[1-player] -= left
+	return next, true, nil
+}
+
+func (s *endgameSearch) rackValue(rack string) int {
+	value := 0
+	for _, letter := range rack {
+		value += int(s.values[string(letter)])
+	}
+	return value
+}
+
+// spread is the lead of the player to move.
+func (p EndgamePosition) spread() int {
+	return p.Scores[p.ToMove] - p.Scores[1-p.ToMove]
+}
@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveEndgame(t *testing.T) {
+	words := []string{"hut", "huts", "ah", "us", "es", "he", "mut", "tut", "sah"}
+	gaddag := NewGaddag(words, Alphabet(LoadLettersPlaySet()))
+	values := LetterValues(LoadLettersPlaySet())
+	layout := LoadStandardLayout()
+	hut := model.Board{
+		{Row: 7, Col: 6, Letter: "h"},
+		{Row: 7, Col: 7, Letter: "u"},
+		{Row: 7, Col: 8, Letter: "t"},
+	}
+
+	t.Run("Play out", func(t *testing.T) {
+		position := EndgamePosition{Board: hut, Racks: [2]string{"s", "q"}, Scores: [2]int{10, 20}}
+
+		solution, err := SolveEndgame(gaddag, position, layout, values, time.Second)
+		assert.NoError(t, err)
+
+		assert.True(t, solution.Exhaustive)
+		assert.Len(t, solution.Plies, 1, "Playing out ends the game")
+		assert.Equal(t, 0, solution.Plies[0].Player)
+		assert.Equal(t, []string{"huts"}, solution.Plies[0].Move.WordList())
+		// huts scores 5, the q left on the opponent's rack is worth 10
+		assert.Equal(t, [2]int{25, 10}, solution.FinalScores)
+		assert.Equal(t, 15, solution.Spread)
+	})
+
+	t.Run("Pass when stuck", func(t *testing.T) {
+		position := EndgamePosition{Board: hut, Racks: [2]string{"q", "s"}, Scores: [2]int{20, 10}}
+
+		solution, err := SolveEndgame(gaddag, position, layout, values, time.Second)
+		assert.NoError(t, err)
+
+		assert.True(t, solution.Exhaustive)
+		assert.Len(t, solution.Plies, 2)
+		assert.True(t, solution.Plies[0].Pass, "Expected a pass without a playable move")
+		assert.Equal(t, 1, solution.Plies[1].Player)
+		assert.Equal(t, [2]int{10, 25}, solution.FinalScores)
+		assert.Equal(t, -15, solution.Spread)
+	})
+
+	t.Run("Both stuck", func(t *testing.T) {
+		position := EndgamePosition{Board: hut, Racks: [2]string{"q", "y"}, Scores: [2]int{20, 10}}
+
+		solution, err := SolveEndgame(gaddag, position, layout, values, time.Second)
+		assert.NoError(t, err)
+
+		assert.Len(t, solution.Plies, 2, "Expected two passes to end the game")
+		assert.Equal(t, 20-int(values["q"]), solution.FinalScores[0])
+		assert.Equal(t, 10-int(values["y"]), solution.FinalScores[1])
+	})
+
+	t.Run("No budget", func(t *testing.T) {
+		position := EndgamePosition{Board: hut, Racks: [2]string{"sah", "mes"}}
+
+		solution, err := SolveEndgame(gaddag, position, layout, values, 0)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, solution.Depth, "Expected the first depth to be searched in any case")
+		assert.NotEmpty(t, solution.Plies)
+	})
+
+	t.Run("Candidates left out", func(t *testing.T) {
+		position := EndgamePosition{Board: hut, Racks: [2]string{"sahmute", "q"}}
+		assert.Greater(t, len(GenerateMoves(gaddag, hut, "sahmute", layout, values)), EndgameCandidates)
+
+		solution, err := SolveEndgame(gaddag, position, layout, values, time.Second)
+		assert.NoError(t, err)
+
+		assert.False(t, solution.Exhaustive, "Expected the search not to be exhaustive with moves left out")
+	})
+}
@@ -0,0 +1,231 @@
+package logic
+
+import (
+	"fmt"
+
+	"buchstaben.go/model"
+)
+
+// ApplyEvent returns the game after the event. The game passed in is not
+// modified.
+func ApplyEvent(game model.UserGame, event model.GameEvent) (model.UserGame, error) {
+	switch event.Type {
+	case model.EventCreated:
+		language := LanguageOrDefault(event.Language)
+		lettersPlaySet, err := LoadLettersPlaySetFor(language)
+		if err != nil {
+			return game, err
+		}
+		return model.UserGame{
+			ID:                 game.ID,
+			User:               game.User,
+			Language:           language,
+			LettersPlaySet:     lettersPlaySet,
+			LastMoveTimestamp:  event.Timestamp,
+			GameStartTimestamp: event.Timestamp,
+			LetterOverAllValue: GetLetterValue(lettersPlaySet),
+			PlayedMoves:        []model.PlayedMove{},
+			Board:              model.Board{},
+		}, nil
+
+	case model.EventMovePlayed:
+		if event.Move == nil {
+			return game, fmt.Errorf("event %q has no move", event.Type)
+		}
+		tiles := MoveTiles(*event.Move)
+		lettersPlaySet := make(model.LettersPlaySet, len(game.LettersPlaySet))
+		copy(lettersPlaySet, game.LettersPlaySet)
+		lettersPlaySet, err := RemoveLetters(lettersPlaySet, tiles)
+		if err != nil {
+			return game, err
+		}
+		board := game.Board
+		if event.Move.Placement != nil {
+			if board, err = PlaceTiles(game.Board, *event.Move.Placement, event.Move.Letters); err != nil {
+				return game, err
+			}
+		}
+		if event.Move.PlayedByMyself {
+			game.Rack = RemoveFromRack(game.Rack, tiles)
+		}
+		game.Board = board
+		return withMoves(game, lettersPlaySet, append(copyMoves(game.PlayedMoves), *event.Move), event.Timestamp), nil
+
+	case model.EventMoveEdited, model.EventMoveDeleted:
+		if event.Index < 0 || event.Index >= len(game.PlayedMoves) {
+			return game, fmt.Errorf("move %d not found, the game has %d moves", event.Index, len(game.PlayedMoves))
+		}
+		previous := game.PlayedMoves[event.Index]
+		lettersPlaySet, err := AddLetters(game.LettersPlaySet, MoveTiles(previous))
+		if err != nil {
+			return game, err
+		}
+		rack := game.Rack
+		if previous.PlayedByMyself {
+			rack += MoveTiles(previous)
+		}
+		var replacement *model.PlayedMove
+		if event.Type == model.EventMoveEdited {
+			replacement = event.Move
+			if event.Move == nil {
+				return game, fmt.Errorf("event %q has no move", event.Type)
+			}
+			if lettersPlaySet, err = RemoveLetters(lettersPlaySet, MoveTiles(*event.Move)); err != nil {
+				return game, err
+			}
+			if event.Move.PlayedByMyself {
+				rack = RemoveFromRack(rack, MoveTiles(*event.Move))
+			}
+		}
+		board, playedMoves, err := ReplaceMove(game.PlayedMoves, event.Index, replacement,
+			LoadStandardLayout(), LetterValues(lettersPlaySet))
+		if err != nil {
+			return game, fmt.Errorf("move %d cannot be changed: %w", event.Index, err)
+		}
+		// my tiles only go back on the rack as long as there is room for them
+		if len([]rune(rack)) <= RackSize {
+			game.Rack = rack
+		}
+		game.Board = board
+		return withMoves(game, lettersPlaySet, playedMoves, event.Timestamp), nil
+
+	case model.EventRackSet:
+		game.Rack = event.Rack
+		return game, nil
+
+	case model.EventEnded:
+		// my rack is not known without one, as for the events recorded
+		// before the result was kept
+		var rack *string
+		if event.Rack != "" || event.PlayedOut {
+			game.Rack = event.Rack
+			rack = &event.Rack
+		}
+		result, err := FinalResult(game, rack)
+		if err != nil {
+			return game, err
+		}
+		game.GameEndTimestamp = event.Timestamp
+		game.Result = &result
+		return game, nil
+	}
+	return game, fmt.Errorf("event type %q is not valid", event.Type)
+}
+
+func withMoves(game model.UserGame, lettersPlaySet model.LettersPlaySet, playedMoves []model.PlayedMove, timestamp string) model.UserGame {
+	game.LettersPlaySet = lettersPlaySet
+	game.LetterOverAllValue = GetLetterValue(lettersPlaySet)
+	game.PlayedMoves = playedMoves
+	game.MyScore, game.OpponentScore = Scores(playedMoves)
+	game.LastMoveTimestamp = timestamp
+	return game
+}
+
+func copyMoves(playedMoves []model.PlayedMove) []model.PlayedMove {
+	moves := make([]model.PlayedMove, len(playedMoves), len(playedMoves)+1)
+	copy(moves, playedMoves)
+	return moves
+}
+
+// ReplayEvents rebuilds the game out of its first count events, all of them
+// if count is negative. Only the id and the user of the game are kept.
+func ReplayEvents(game model.UserGame, events []model.GameEvent, count int) (model.UserGame, error) {
+	if count < 0 || count > len(events) {
+		count = len(events)
+	}
+	game = model.UserGame{ID: game.ID, User: game.User}
+	for i, event := range events[:count] {
+		var err error
+		if game, err = ApplyEvent(game, event); err != nil {
+			return model.UserGame{}, fmt.Errorf("event %d: %w", i, err)
+		}
+	}
+	game.Events = events[:count]
+	return game, nil
+}
+
+// ReplayMoves rebuilds the game out of a full tile set and its first count
+// moves, all of them if count is negative. Edits and deletes are already
+// part of the moves, so the event log is not needed.
+func ReplayMoves(game model.UserGame, count int) (model.GameReplay, error) {
+	if count < 0 {
+		count = len(game.PlayedMoves)
+	}
+	if count > len(game.PlayedMoves) {
+		return model.GameReplay{}, fmt.Errorf("move %d not found, the game has %d moves", count, len(game.PlayedMoves))
+	}
+	events := []model.GameEvent{{Type: model.EventCreated, Timestamp: game.GameStartTimestamp, Language: game.Language}}
+	for i := range game.PlayedMoves[:count] {
+		events = append(events, model.GameEvent{
+			Type:      model.EventMovePlayed,
+			Timestamp: game.PlayedMoves[i].Timestamp,
+			Move:      &game.PlayedMoves[i],
+		})
+	}
+	replayed, err := ReplayEvents(game, events, -1)
+	if err != nil {
+		return model.GameReplay{}, err
+	}
+
+	replay := model.GameReplay{
+		ID:                 game.ID,
+		User:               game.User,
+		Move:               count,
+		TotalMoves:         len(game.PlayedMoves),
+		LettersPlaySet:     replayed.LettersPlaySet,
+		LetterOverAllValue: replayed.LetterOverAllValue,
+		RemindingLetters:   GetRemindingsLetterCount(replayed.LettersPlaySet),
+		MyScore:            replayed.MyScore,
+		OpponentScore:      replayed.OpponentScore,
+		Board:              replayed.Board,
+	}
+	if count > 0 {
+		lastMove := game.PlayedMoves[count-1]
+		replay.LastMove = &lastMove
+	}
+	return replay, nil
+}
+
+// GameEvents returns the event log of the game. Games stored before the log
+// was kept get one made up of their stored moves, RecordEvent keeps it as
+// their log from the next event on.
+func GameEvents(game model.UserGame) []model.GameEvent {
+	if len(game.Events) > 0 {
+		return game.Events
+	}
+	events := []model.GameEvent{{
+		Type:      model.EventCreated,
+		Timestamp: game.GameStartTimestamp,
+		Language:  LanguageOrDefault(game.Language),
+	}}
+	for _, move := range game.PlayedMoves {
+		move := move
+		events = append(events, model.GameEvent{
+			Type:      model.EventMovePlayed,
+			Timestamp: move.Timestamp,
+			Move:      &move,
+		})
+	}
+	if game.Rack != "" {
+		events = append(events, model.GameEvent{Type: model.EventRackSet, Timestamp: game.LastMoveTimestamp, Rack: game.Rack})
+	}
+	if game.GameEndTimestamp != "" {
+		events = append(events, model.GameEvent{Type: model.EventEnded, Timestamp: game.GameEndTimestamp, Rack: game.Rack})
+	}
+	return events
+}
+
+// RecordEvent applies the event to the game and appends it to the log, a
+// created event starts a new log.
+func RecordEvent(game model.UserGame, event model.GameEvent) (model.UserGame, error) {
+	var events []model.GameEvent
+	if event.Type != model.EventCreated {
+		events = GameEvents(game)
+	}
+	updated, err := ApplyEvent(game, event)
+	if err != nil {
+		return game, err
+	}
+	updated.Events = append(events[:len(events):len(events)], event)
+	return updated, nil
+}
@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayEvents(t *testing.T) {
+	hut := model.PlayedMove{Letters: "hut", Points: 4, PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}
+	hat := model.PlayedMove{Letters: "hat", Points: 4, PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}
+	s := model.PlayedMove{Letters: "s", Points: 5, Placement: &model.Placement{Row: 7, Col: 9, Direction: model.Horizontal}}
+	events := []model.GameEvent{
+		{Type: model.EventCreated, Timestamp: "2024-01-01 10:00:00", Language: "de"},
+		{Type: model.EventRackSet, Timestamp: "2024-01-01 10:00:01", Rack: "thuabc"},
+		{Type: model.EventMovePlayed, Timestamp: "2024-01-01 10:01:00", Move: &hut},
+		{Type: model.EventMovePlayed, Timestamp: "2024-01-01 10:02:00", Move: &s},
+		{Type: model.EventMoveEdited, Timestamp: "2024-01-01 10:03:00", Index: 0, Move: &hat},
+		{Type: model.EventMoveDeleted, Timestamp: "2024-01-01 10:04:00", Index: 1},
+		{Type: model.EventEnded, Timestamp: "2024-01-01 10:05:00"},
+	}
+
+	game, err := ReplayEvents(model.UserGame{ID: "1", User: "testuser"}, events, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", game.User)
+	assert.Equal(t, "1", game.ID, "Expected the id to be kept")
+	assert.Equal(t, []model.PlayedMove{hat}, game.PlayedMoves)
+	assert.Len(t, game.Board, 3)
+	assert.Equal(t, "a", game.Board[1].Letter)
+	assert.Equal(t, "bcu", game.Rack, "Expected the tiles of the edited move to be swapped on the rack")
+	played, _ := RemoveLetters(LoadLettersPlaySet(), "hat")
+	assert.EqualValues(t, played, game.LettersPlaySet)
+	assert.Equal(t, GetLetterValue(played), game.LetterOverAllValue)
+	assert.Equal(t, "2024-01-01 10:00:00", game.GameStartTimestamp)
+	assert.Equal(t, "2024-01-01 10:05:00", game.GameEndTimestamp)
+	assert.Len(t, game.Events, len(events))
+
+	game, err = ReplayEvents(model.UserGame{ID: "1", User: "testuser"}, events, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.PlayedMove{hut, s}, game.PlayedMoves, "Expected the game after the first events")
+	assert.Len(t, game.Board, 4)
+	assert.Empty(t, game.GameEndTimestamp)
+
+	undone := append(events[:4:4], model.GameEvent{Type: model.EventMoveDeleted, Index: 1}, model.GameEvent{Type: model.EventMoveDeleted, Index: 0})
+	game, err = ReplayEvents(model.UserGame{ID: "1", User: "testuser"}, undone, -1)
+	assert.NoError(t, err)
+	assert.Empty(t, game.PlayedMoves)
+	assert.Equal(t, "abchut", game.Rack, "Expected my deleted tiles to be back on the rack")
+
+	_, err = ReplayEvents(model.UserGame{ID: "1", User: "testuser"}, append(events[:6:6], model.GameEvent{Type: model.EventMoveDeleted, Index: 5}), -1)
+	assert.Error(t, err, "Expected error for a move out of range")
+
+	_, err = ReplayEvents(model.UserGame{ID: "1", User: "testuser"}, []model.GameEvent{{Type: "unknown"}}, -1)
+	assert.Error(t, err, "Expected error for unknown event type")
+}
+
+func TestGameEvents(t *testing.T) {
+	game := model.UserGame{
+		User:               "testuser",
+		LettersPlaySet:     LoadLettersPlaySet(),
+		GameStartTimestamp: "2024-01-01 10:00:00",
+		PlayedMoves:        []model.PlayedMove{{Letters: "ab", Timestamp: "2024-01-01 10:01:00"}},
+	}
+	game.LettersPlaySet, _ = RemoveLetters(game.LettersPlaySet, "ab")
+
+	events := GameEvents(game)
+	assert.Len(t, events, 2, "Expected the log to be made up for games without one")
+	assert.Equal(t, model.EventCreated, events[0].Type)
+	assert.Equal(t, DefaultLanguage, events[0].Language)
+
+	replayed, err := ReplayEvents(game, events, -1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, game.LettersPlaySet, replayed.LettersPlaySet)
+
+	recorded, err := RecordEvent(game, model.GameEvent{Type: model.EventRackSet, Rack: "xy"})
+	assert.NoError(t, err)
+	assert.Equal(t, "xy", recorded.Rack)
+	assert.Len(t, recorded.Events, 3)
+	assert.Empty(t, game.Events, "Expected the game not to be modified")
+
+	_, err = RecordEvent(game, model.GameEvent{Type: model.EventMovePlayed, Move: &model.PlayedMove{Letters: "1"}})
+	assert.Error(t, err)
+}
+
+func TestReplayMoves(t *testing.T) {
+	hut := model.PlayedMove{Letters: "hut", Points: 4, PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}
+	s := model.PlayedMove{Letters: "s", Points: 5, Placement: &model.Placement{Row: 7, Col: 9, Direction: model.Horizontal}}
+	// moves stored before there was a board
+	ab := model.PlayedMove{Letters: "ab", Points: 6, PlayedByMyself: true}
+	game := model.UserGame{ID: "1", User: "testuser", Language: "de", PlayedMoves: []model.PlayedMove{hut, s, ab}}
+
+	replay, err := ReplayMoves(game, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, replay.Move)
+	assert.Equal(t, 3, replay.TotalMoves)
+	assert.EqualValues(t, LoadLettersPlaySet(), replay.LettersPlaySet, "Expected a full set before the first move")
+	assert.Empty(t, replay.Board)
+	assert.Nil(t, replay.LastMove)
+
+	replay, err = ReplayMoves(game, 2)
+	assert.NoError(t, err)
+	played, _ := RemoveLetters(LoadLettersPlaySet(), "huts")
+	assert.EqualValues(t, played, replay.LettersPlaySet)
+	assert.Equal(t, GetLetterValue(played), replay.LetterOverAllValue)
+	assert.Equal(t, GetRemindingsLetterCount(played), replay.RemindingLetters)
+	assert.Equal(t, 4, replay.MyScore)
+	assert.Equal(t, 5, replay.OpponentScore)
+	assert.Len(t, replay.Board, 4)
+	assert.Equal(t, &s, replay.LastMove)
+
+	replay, err = ReplayMoves(game, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, replay.Move, "Expected all moves without a count")
+	assert.Equal(t, 10, replay.MyScore)
+	assert.Len(t, replay.Board, 4, "Expected moves without placement to leave the board alone")
+
+	_, err = ReplayMoves(game, 4)
+	assert.EqualError(t, err, "move 4 not found, the game has 3 moves")
+}
@@ -0,0 +1,86 @@
+package logic
+
+// separator splits the reversed prefix from the suffix of a GADDAG path.
+const separator = '>'
+
+type gaddagNode struct {
+	children map[rune]*gaddagNode
+	terminal bool
+}
+
+func (n *gaddagNode) child(letter rune) *gaddagNode {
+	if n == nil {
+		return nil
+	}
+	return n.children[letter]
+}
+
+// Gaddag stores every word once per split point as reversed prefix,
+// separator and suffix, so words can be built outwards from any letter.
+type Gaddag struct {
+	root     *gaddagNode
+	alphabet []rune
+	count    int
+}
+
+// NewGaddag builds a GADDAG of all words between two and BoardSize letters
+// which only use letters of the alphabet.
+func NewGaddag(words []string, alphabet []rune) *Gaddag {
+	g := &Gaddag{root: &gaddagNode{}, alphabet: alphabet}
+	allowed := AlphabetSet(alphabet)
+
+	for _, word := range words {
+		word = NormalizeWord(word)
+		if !IsPlayableWord(word, allowed) {
+			continue
+		}
+		runes := []rune(word)
+		for split := 1; split <= len(runes); split++ {
+			node := g.root
+			for i := split - 1; i >= 0; i-- {
+				node = node.add(runes[i])
+			}
+			node = node.add(separator)
+			for _, letter := range runes[split:] {
+				node = node.add(letter)
+			}
+			node.terminal = true
+		}
+		g.count++
+	}
+	return g
+}
+
+func (n *gaddagNode) add(letter rune) *gaddagNode {
+	if n.children == nil {
+		n.children = make(map[rune]*gaddagNode)
+	}
+	next, exists := n.children[letter]
+	if !exists {
+		next = &gaddagNode{}
+		n.children[letter] = next
+	}
+	return next
+}
+
+// Len returns the number of words stored.
+func (g *Gaddag) Len() int {
+	return g.count
+}
+
+func (g *Gaddag) Contains(word string) bool {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return false
+	}
+	node := g.root.child(runes[0]).child(separator)
+	for _, letter := range runes[1:] {
+		node = node.child(letter)
+	}
+	return node != nil && node.terminal
+}
+
+// Alphabet returns the letters the words are made of.
+func (g *Gaddag) Alphabet() []rune {
+	return g.alphabet
+}
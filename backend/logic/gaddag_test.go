@@ -0,0 +1,22 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaddagContains(t *testing.T) {
+	gaddag := NewGaddag([]string{"hut", "Huts", "a", "übel", "web-seite", "abcdefghijklmnop"}, Alphabet(LoadLettersPlaySet()))
+
+	assert.Equal(t, 3, gaddag.Len(), "Only valid words should be stored")
+	assert.True(t, gaddag.Contains("hut"), "Word 'hut' should be found")
+	assert.True(t, gaddag.Contains("huts"), "Words should be stored in lower case")
+	assert.True(t, gaddag.Contains("übel"), "Words with umlauts should be found")
+	assert.False(t, gaddag.Contains("hu"), "Prefixes should not be found")
+	assert.False(t, gaddag.Contains("ut"), "Suffixes should not be found")
+	assert.False(t, gaddag.Contains("a"), "Single letters should not be stored")
+	assert.False(t, gaddag.Contains("web-seite"), "Words with letters outside the alphabet should not be stored")
+	assert.False(t, gaddag.Contains("abcdefghijklmnop"), "Words longer than the board should not be stored")
+	assert.False(t, gaddag.Contains(""), "Empty word should not be found")
+}
@@ -0,0 +1,15 @@
+package logic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// NewGameID returns a random id for a game.
+func NewGameID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,389 @@
+package logic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"buchstaben.go/model"
+)
+
+// GCGMyself is my nickname in exported GCG files, I am always player1.
+const GCGMyself = "me"
+
+// ExportGCG renders the moves of the game in the GCG format of Quackle and
+// other analysis tools. Racks are written where the event log knows them,
+// otherwise the tiles played. Moves stored without a placement have no
+// position and are written as notes with their points and the running total,
+// which analysis tools skip, so the totals of the later turns include points
+// of turns they do not see.
+func ExportGCG(game model.UserGame) (string, error) {
+	opponent := gcgNick(game.User)
+	if opponent == GCGMyself {
+		opponent = "opponent"
+	}
+	var gcg strings.Builder
+	gcg.WriteString("#character-encoding UTF-8\n")
+	fmt.Fprintf(&gcg, "#player1 %s %s\n", GCGMyself, GCGMyself)
+	fmt.Fprintf(&gcg, "#player2 %s %s\n", opponent, game.User)
+
+	racks := moveRacks(game)
+	board := model.Board{}
+	mine, theirs := 0, 0
+	for i, move := range game.PlayedMoves {
+		nick, total := opponent, &theirs
+		if move.PlayedByMyself {
+			nick, total = GCGMyself, &mine
+		}
+		*total += int(move.Points)
+		tiles := MoveTiles(move)
+
+		if move.Placement == nil {
+			fmt.Fprintf(&gcg, "#note %s played %s for %d points without a position, total %d\n", nick, gcgTiles(tiles), move.Points, *total)
+			continue
+		}
+		position, word, err := gcgPlay(board, *move.Placement, move.Letters)
+		if err != nil {
+			return "", fmt.Errorf("move %d: %w", i, err)
+		}
+		if board, err = PlaceTiles(board, *move.Placement, move.Letters); err != nil {
+			return "", fmt.Errorf("move %d: %w", i, err)
+		}
+		rack := tiles
+		if racks != nil && racks[i] != "" {
+			rack = racks[i]
+		}
+		fmt.Fprintf(&gcg, ">%s: %s %s %s +%d %d\n", nick, gcgTiles(rack), position, word, move.Points, *total)
+	}
+
+	if game.Result != nil {
+		myRack := game.Rack
+		opponentRack := ""
+		if unseen, err := UnseenTiles(game.LettersPlaySet, game.Rack); err == nil && GetRemindingsLetterCount(unseen) <= RackSize {
+			opponentRack = setTiles(unseen)
+		}
+		mine += game.Result.MyRackAdjustment
+		theirs += game.Result.OpponentRackAdjustment
+		gcg.WriteString(gcgRackPoints(GCGMyself, myRack, opponentRack, game.Result.MyRackAdjustment, mine))
+		gcg.WriteString(gcgRackPoints(opponent, opponentRack, myRack, game.Result.OpponentRackAdjustment, theirs))
+	}
+	return gcg.String(), nil
+}
+
+// gcgNick turns a username into a nickname without spaces and colons.
+func gcgNick(user string) string {
+	nick := strings.Join(strings.Fields(strings.ReplaceAll(user, ":", "")), "_")
+	if nick == "" {
+		return "opponent"
+	}
+	return nick
+}
+
+// gcgTiles writes tiles in upper case with "?" for the blank.
+func gcgTiles(tiles string) string {
+	return strings.ReplaceAll(strings.ToUpper(tiles), "*", "?")
+}
+
+// gcgRackPoints writes the points won for the rack of the other player or
+// lost for the own rack at the end of the game.
+func gcgRackPoints(nick, rack, otherRack string, points, total int) string {
+	switch {
+	case points > 0:
+		return fmt.Sprintf(">%s: (%s) +%d %d\n", nick, gcgTiles(otherRack), points, total)
+	case points < 0:
+		return fmt.Sprintf(">%s: %s (%s) %d %d\n", nick, gcgTiles(rack), gcgTiles(rack), points, total)
+	}
+	return ""
+}
+
+// gcgPlay returns the GCG position and word of a placement on the board.
+// The word starts at the first tile of the main word, tiles already on the
+// board are written as ".", letters played as blank in lower case.
+func gcgPlay(board model.Board, placement model.Placement, letters string) (string, string, error) {
+	tiles, err := NewTiles(board, placement, letters)
+	if err != nil {
+		return "", "", err
+	}
+	dRow, dCol, err := step(placement.Direction)
+	if err != nil {
+		return "", "", err
+	}
+	grid := NewGrid(board)
+	for _, tile := range tiles {
+		grid[tile.Row][tile.Col] = tile
+	}
+	isNew := make(map[[2]int]bool, len(tiles))
+	for _, tile := range tiles {
+		isNew[[2]int{tile.Row, tile.Col}] = true
+	}
+
+	row, col := tiles[0].Row, tiles[0].Col
+	for grid.occupied(row-dRow, col-dCol) {
+		row, col = row-dRow, col-dCol
+	}
+	position := fmt.Sprintf("%d%c", row+1, 'A'+col)
+	if placement.Direction == model.Vertical {
+		position = fmt.Sprintf("%c%d", 'A'+col, row+1)
+	}
+
+	var word strings.Builder
+	for ; grid.occupied(row, col); row, col = row+dRow, col+dCol {
+		tile := grid[row][col]
+		switch {
+		case !isNew[[2]int{row, col}]:
+			word.WriteString(".")
+		case tile.Blank:
+			word.WriteString(strings.ToLower(tile.Letter))
+		default:
+			word.WriteString(strings.ToUpper(tile.Letter))
+		}
+	}
+	return position, word.String(), nil
+}
+
+// moveRacks returns the rack I had before each of my moves as far as the
+// event log tells, "" where it is not known. It returns nil if the log does
+// not add up to the moves of the game.
+func moveRacks(game model.UserGame) []string {
+	racks := []string{}
+	replayed := model.UserGame{}
+	for _, event := range GameEvents(game) {
+		rack := ""
+		if event.Type == model.EventMovePlayed && event.Move != nil && event.Move.PlayedByMyself &&
+			holdsTiles(replayed.Rack, MoveTiles(*event.Move)) {
+			rack = replayed.Rack
+		}
+		var err error
+		if replayed, err = ApplyEvent(replayed, event); err != nil {
+			return nil
+		}
+		switch event.Type {
+		case model.EventMovePlayed:
+			racks = append(racks, rack)
+		case model.EventMoveEdited:
+			racks[event.Index] = ""
+		case model.EventMoveDeleted:
+			racks = append(racks[:event.Index], racks[event.Index+1:]...)
+		}
+	}
+	if len(racks) != len(game.PlayedMoves) {
+		return nil
+	}
+	return racks
+}
+
+// holdsTiles reports if all tiles are on the rack.
+func holdsTiles(rack, tiles string) bool {
+	return len([]rune(RemoveFromRack(rack, tiles))) == len([]rune(rack))-len([]rune(tiles))
+}
+
+// setTiles lists the tiles left in the set.
+func setTiles(lettersPlaySet model.LettersPlaySet) string {
+	var tiles strings.Builder
+	for _, l := range lettersPlaySet {
+		tiles.WriteString(strings.Repeat(l.Letter, int(l.CurrentCount)))
+	}
+	return tiles.String()
+}
+
+// ImportGCG builds a new game in the language out of a GCG file. me is my
+// nickname in the file, player1 without it, the other player becomes the
+// user of the game. Every play is put on the board and its tiles are taken
+// out of the letter set with RemoveLetters, so tiles the set does not have
+// fail the import. The points of the file are kept, the words come from the
+// board. Passes, exchanges and the points for the racks at the end are
+// skipped, withdrawn plays are removed again.
+func ImportGCG(gcg, me, language, timestamp string) (model.UserGame, error) {
+	lines := strings.Split(gcg, "\n")
+	players := map[string]string{}
+	player1 := ""
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "#player1", "#player2":
+			if len(fields) < 2 {
+				return model.UserGame{}, fmt.Errorf("line %d: %s has no nickname", i+1, fields[0])
+			}
+			name := strings.Join(fields[2:], " ")
+			if name == "" {
+				name = fields[1]
+			}
+			players[fields[1]] = name
+			if fields[0] == "#player1" {
+				player1 = fields[1]
+			}
+		case "#character-encoding":
+			if len(fields) < 2 || !strings.EqualFold(fields[1], "UTF-8") {
+				return model.UserGame{}, fmt.Errorf("line %d: only UTF-8 encoded files are supported", i+1)
+			}
+		}
+	}
+	if len(players) != 2 {
+		return model.UserGame{}, fmt.Errorf("the file has to name two different players with #player1 and #player2")
+	}
+	if me == "" {
+		me = player1
+	}
+	if _, exists := players[me]; !exists {
+		return model.UserGame{}, fmt.Errorf("player %q is not in the file", me)
+	}
+	user := ""
+	for nick, name := range players {
+		if nick != me {
+			user = name
+		}
+	}
+
+	game, err := RecordEvent(model.UserGame{User: user}, model.GameEvent{
+		Type:      model.EventCreated,
+		Timestamp: timestamp,
+		Language:  LanguageOrDefault(language),
+	})
+	if err != nil {
+		return model.UserGame{}, err
+	}
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, ">") {
+			continue
+		}
+		nick, turn, found := strings.Cut(line[1:], ":")
+		if !found {
+			return model.UserGame{}, fmt.Errorf("line %d: %q has no player", i+1, line)
+		}
+		if _, exists := players[nick]; !exists {
+			return model.UserGame{}, fmt.Errorf("line %d: player %q is not declared", i+1, nick)
+		}
+		if game, err = importGCGTurn(game, nick == me, strings.Fields(turn), timestamp); err != nil {
+			return model.UserGame{}, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	return game, nil
+}
+
+// importGCGTurn applies a turn of a GCG file to the game.
+func importGCGTurn(game model.UserGame, myself bool, fields []string, timestamp string) (model.UserGame, error) {
+	if len(fields) > 0 && isGCGRack(fields[0]) {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return game, fmt.Errorf("the turn is empty")
+	}
+	switch {
+	case fields[0] == "--":
+		last := len(game.PlayedMoves) - 1
+		if last < 0 || game.PlayedMoves[last].PlayedByMyself != myself {
+			return game, fmt.Errorf("there is no play to withdraw")
+		}
+		return RecordEvent(game, model.GameEvent{Type: model.EventMoveDeleted, Timestamp: timestamp, Index: last})
+	case strings.HasPrefix(fields[0], "-"), strings.HasPrefix(fields[0], "("):
+		// passes, exchanges and points without a play
+		return game, nil
+	}
+
+	if len(fields) < 3 {
+		return game, fmt.Errorf("a play needs a position, a word and a score")
+	}
+	placement, letters, err := parseGCGPlay(game.Board, fields[0], fields[1])
+	if err != nil {
+		return game, err
+	}
+	points, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "+"), 10, 32)
+	if err != nil {
+		return game, fmt.Errorf("score %q is not valid", fields[2])
+	}
+	moveScore, err := ScoreMove(game.Board, placement, letters, LoadStandardLayout(), LetterValues(game.LettersPlaySet))
+	if err != nil {
+		return game, err
+	}
+	move := model.PlayedMove{
+		Letters:        letters,
+		Words:          moveScore.WordList(),
+		PlayedByMyself: myself,
+		Timestamp:      timestamp,
+		Points:         uint(points),
+		Placement:      &placement,
+	}
+	return RecordEvent(game, model.GameEvent{Type: model.EventMovePlayed, Timestamp: timestamp, Move: &move})
+}
+
+// isGCGRack reports if the field is a rack, which has no digits, dashes or
+// parentheses unlike the other fields a turn starts with.
+func isGCGRack(field string) bool {
+	for _, letter := range field {
+		if letter != '?' && !unicode.IsLetter(letter) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseGCGPlay returns the placement and the new letters of a GCG play.
+// Tiles already on the board are "." or letters in parentheses.
+func parseGCGPlay(board model.Board, position, word string) (model.Placement, string, error) {
+	placement := model.Placement{Direction: model.Horizontal}
+	rowText := strings.TrimRightFunc(position, unicode.IsLetter)
+	colText := position[len(rowText):]
+	if rowText == "" || rowText == position {
+		rowText = strings.TrimLeftFunc(position, unicode.IsLetter)
+		colText = position[:len(position)-len(rowText)]
+		placement.Direction = model.Vertical
+	}
+	row, err := strconv.Atoi(rowText)
+	if err != nil || len(colText) != 1 || row < 1 {
+		return placement, "", fmt.Errorf("position %q is not valid", position)
+	}
+	row, col := row-1, int(unicode.ToUpper(rune(colText[0]))-'A')
+	if !onBoard(row, col) {
+		return placement, "", fmt.Errorf("position %q is not on the board", position)
+	}
+	dRow, dCol, _ := step(placement.Direction)
+
+	grid := NewGrid(board)
+	var letters []rune
+	through := false
+	placed := false
+	for _, letter := range word {
+		switch letter {
+		case '(':
+			through = true
+			continue
+		case ')':
+			through = false
+			continue
+		}
+		if !onBoard(row, col) {
+			return placement, "", fmt.Errorf("word %q does not fit on the board", word)
+		}
+		if through || letter == '.' {
+			if !grid.occupied(row, col) {
+				return placement, "", fmt.Errorf("word %q plays through an empty square", word)
+			}
+			if letter != '.' && grid[row][col].Letter != string(unicode.ToLower(letter)) {
+				return placement, "", fmt.Errorf("word %q does not match the board", word)
+			}
+		} else {
+			if grid.occupied(row, col) {
+				return placement, "", fmt.Errorf("word %q places a tile on an occupied square", word)
+			}
+			if !placed {
+				placement.Row, placement.Col = row, col
+				placed = true
+			}
+			if unicode.IsLower(letter) {
+				placement.Blanks = append(placement.Blanks, len(letters))
+			}
+			letters = append(letters, unicode.ToLower(letter))
+		}
+		row, col = row+dRow, col+dCol
+	}
+	if len(letters) == 0 {
+		return placement, "", fmt.Errorf("word %q places no tiles", word)
+	}
+	return placement, string(letters), nil
+}
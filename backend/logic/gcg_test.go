@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// gcgGame plays the moves of a test game through the event log.
+func gcgGame(t *testing.T, events ...model.GameEvent) model.UserGame {
+	t.Helper()
+
+	game := model.UserGame{ID: "1", User: "anna b"}
+	events = append([]model.GameEvent{{Type: model.EventCreated, Language: "de"}}, events...)
+	for _, event := range events {
+		var err error
+		if game, err = RecordEvent(game, event); err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+	}
+	return game
+}
+
+func TestExportGCG(t *testing.T) {
+	game := gcgGame(t,
+		model.GameEvent{Type: model.EventRackSet, Rack: "hutabc*"},
+		model.GameEvent{Type: model.EventMovePlayed, Move: &model.PlayedMove{Letters: "hut", Points: 8, PlayedByMyself: true,
+			Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}},
+		model.GameEvent{Type: model.EventMovePlayed, Move: &model.PlayedMove{Letters: "ser", Points: 5,
+			Placement: &model.Placement{Row: 8, Col: 6, Direction: model.Vertical, Blanks: []int{2}}}},
+		model.GameEvent{Type: model.EventMovePlayed, Move: &model.PlayedMove{Letters: "ab", Points: 4, PlayedByMyself: true}},
+	)
+
+	gcg, err := ExportGCG(game)
+	assert.NoError(t, err)
+	assert.Equal(t, `#character-encoding UTF-8
+#player1 me me
+#player2 anna_b anna b
+>me: HUTABC? 8G HUT +8 8
+>anna_b: SE? G8 .SEr +5 5
+#note me played AB for 4 points without a position, total 12
+`, gcg)
+
+	// the opponent played out and I am left with the last tiles
+	game.Rack = "c*"
+	game.LettersPlaySet = model.LettersPlaySet{
+		{Letter: "c", OriginalCount: 2, CurrentCount: 1, Value: 4},
+		{Letter: "*", OriginalCount: 2, CurrentCount: 1, Value: 0},
+	}
+	game.Result = &model.GameResult{MyRackAdjustment: -4, OpponentRackAdjustment: 4}
+	gcg, err = ExportGCG(game)
+	assert.NoError(t, err)
+	assert.Contains(t, gcg, ">me: C? (C?) -4 8\n>anna_b: (C?) +4 9\n", "Expected the rack points at the end")
+}
+
+func TestImportGCG(t *testing.T) {
+	gcg := `#character-encoding UTF-8
+#player1 me me
+#player2 anna_b anna b
+>me: HUTABC? 8G HUT +8 8
+>anna_b: SE? G8 .SEr +5 5
+>me: ABC -ABC +0 8
+>anna_b: XYZ 9H XYZ +30 35
+>anna_b: XYZ -- -30 5
+>me: (ACE) +5 13
+`
+	game, err := ImportGCG(gcg, "", "de", "2024-01-01 10:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "anna b", game.User)
+	assert.Equal(t, "de", game.Language)
+	if assert.Len(t, game.PlayedMoves, 2, "Expected the exchange and the withdrawn play to be left out") {
+		assert.Equal(t, model.PlayedMove{Letters: "hut", Words: []string{"hut"}, PlayedByMyself: true, Timestamp: "2024-01-01 10:00:00", Points: 8,
+			Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}, game.PlayedMoves[0])
+		assert.Equal(t, model.PlayedMove{Letters: "ser", Words: []string{"hser"}, Timestamp: "2024-01-01 10:00:00", Points: 5,
+			Placement: &model.Placement{Row: 8, Col: 6, Direction: model.Vertical, Blanks: []int{2}}}, game.PlayedMoves[1])
+	}
+	assert.Equal(t, 8, game.MyScore)
+	assert.Equal(t, 5, game.OpponentScore)
+	played, _ := RemoveLetters(LoadLettersPlaySet(), "hutse*")
+	assert.EqualValues(t, played, game.LettersPlaySet)
+
+	game, err = ImportGCG(gcg, "anna_b", "", "2024-01-01 10:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "me", game.User)
+	assert.False(t, game.PlayedMoves[0].PlayedByMyself)
+
+	exported, err := ExportGCG(gcgGame(t,
+		model.GameEvent{Type: model.EventMovePlayed, Move: &game.PlayedMoves[0]},
+		model.GameEvent{Type: model.EventMovePlayed, Move: &game.PlayedMoves[1]},
+	))
+	assert.NoError(t, err)
+	imported, err := ImportGCG(exported, "", "de", "2024-01-01 10:00:00")
+	assert.NoError(t, err)
+	assert.Equal(t, game.PlayedMoves, imported.PlayedMoves, "Expected an exported game to import again")
+
+	tests := []struct {
+		name     string
+		gcg      string
+		expected string
+	}{
+		{"missing player", "#player1 me\n>me: 8G HUT +8 8\n", "the file has to name two different players with #player1 and #player2"},
+		{"unknown player", "#player1 me\n#player2 anna\n>bert: 8G HUT +8 8\n", `line 3: player "bert" is not declared`},
+		{"invalid position", "#player1 me\n#player2 anna\n>me: 8 HUT +8 8\n", `line 3: position "8" is not valid`},
+		{"off the board", "#player1 me\n#player2 anna\n>me: 8N HUT +8 8\n", `line 3: word "HUT" does not fit on the board`},
+		{"invalid score", "#player1 me\n#player2 anna\n>me: 8G HUT x 8\n", `line 3: score "x" is not valid`},
+		{"unavailable tile", "#player1 me\n#player2 anna\n>me: 8G QUQ +8 8\n", `line 3: letter "q" is not available anymore, "Play Move" ignored`},
+		{"invalid tile", "#player1 me\n#player2 anna\n>me: 8G ÇA +8 8\n", `line 3: letter "ç" is not valid, "Play Move" ignored`},
+		{"through an empty square", "#player1 me\n#player2 anna\n>me: 8G .UT +8 8\n", `line 3: word ".UT" plays through an empty square`},
+		{"nothing to withdraw", "#player1 me\n#player2 anna\n>me: -- -8 0\n", "line 3: there is no play to withdraw"},
+		{"other encoding", "#character-encoding ISO-8859-1\n#player1 me\n#player2 anna\n", "line 1: only UTF-8 encoded files are supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportGCG(tt.gcg, "", "de", "2024-01-01 10:00:00")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+
+	_, err = ImportGCG(gcg, "bert", "de", "2024-01-01 10:00:00")
+	assert.EqualError(t, err, `player "bert" is not in the file`)
+}
@@ -2,42 +2,17 @@ package logic
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"buchstaben.go/model"
 )
 
+// LoadLettersPlaySet returns a full set of tiles of the default language.
 func LoadLettersPlaySet() model.LettersPlaySet {
-	lettersPlaySet := model.LettersPlaySet{
-		{Letter: "a", OriginalCount: 5, CurrentCount: 5, Value: 1},
-		{Letter: "b", OriginalCount: 2, CurrentCount: 2, Value: 2},
-		{Letter: "c", OriginalCount: 2, CurrentCount: 2, Value: 4},
-		{Letter: "d", OriginalCount: 5, CurrentCount: 5, Value: 1},
-		{Letter: "e", OriginalCount: 14, CurrentCount: 14, Value: 1},
-		{Letter: "f", OriginalCount: 2, CurrentCount: 2, Value: 4},
-		{Letter: "g", OriginalCount: 3, CurrentCount: 3, Value: 2},
-		{Letter: "h", OriginalCount: 4, CurrentCount: 4, Value: 2},
-		{Letter: "i", OriginalCount: 6, CurrentCount: 6, Value: 1},
-		{Letter: "j", OriginalCount: 1, CurrentCount: 1, Value: 6},
-		{Letter: "k", OriginalCount: 2, CurrentCount: 2, Value: 4},
-		{Letter: "l", OriginalCount: 3, CurrentCount: 3, Value: 2},
-		{Letter: "m", OriginalCount: 4, CurrentCount: 4, Value: 3},
-		{Letter: "n", OriginalCount: 9, CurrentCount: 9, Value: 1},
-		{Letter: "o", OriginalCount: 3, CurrentCount: 3, Value: 2},
-		{Letter: "p", OriginalCount: 1, CurrentCount: 1, Value: 5},
-		{Letter: "q", OriginalCount: 1, CurrentCount: 1, Value: 10},
-		{Letter: "r", OriginalCount: 6, CurrentCount: 6, Value: 1},
-		{Letter: "s", OriginalCount: 7, CurrentCount: 7, Value: 1},
-		{Letter: "t", OriginalCount: 6, CurrentCount: 6, Value: 1},
-		{Letter: "u", OriginalCount: 6, CurrentCount: 6, Value: 1},
-		{Letter: "v", OriginalCount: 1, CurrentCount: 1, Value: 6},
-		{Letter: "w", OriginalCount: 1, CurrentCount: 1, Value: 3},
-		{Letter: "x", OriginalCount: 1, CurrentCount: 1, Value: 8},
-		{Letter: "y", OriginalCount: 1, CurrentCount: 1, Value: 10},
-		{Letter: "z", OriginalCount: 1, CurrentCount: 1, Value: 3},
-		{Letter: "ä", OriginalCount: 1, CurrentCount: 1, Value: 6},
-		{Letter: "ö", OriginalCount: 1, CurrentCount: 1, Value: 8},
-		{Letter: "ü", OriginalCount: 1, CurrentCount: 1, Value: 6},
-		{Letter: "*", OriginalCount: 2, CurrentCount: 2, Value: 0},
+	lettersPlaySet, err := LoadLettersPlaySetFor(DefaultLanguage)
+	if err != nil {
+		panic(err)
 	}
 	return lettersPlaySet
 }
@@ -80,3 +55,45 @@ func GetRemindingsLetterCount(lettersPlaySet model.LettersPlaySet) uint {
 	}
 	return remindingLetterCount
 }
+
+// Alphabet returns the letters of the set without the blank.
+func Alphabet(lettersPlaySet model.LettersPlaySet) []rune {
+	alphabet := make([]rune, 0, len(lettersPlaySet))
+	for _, l := range lettersPlaySet {
+		if l.Letter == "*" {
+			continue
+		}
+		alphabet = append(alphabet, []rune(l.Letter)...)
+	}
+	return alphabet
+}
+
+func AlphabetSet(alphabet []rune) map[rune]bool {
+	set := make(map[rune]bool, len(alphabet))
+	for _, letter := range alphabet {
+		set[letter] = true
+	}
+	return set
+}
+
+// NormalizeWord lower cases the word and spells "ß" as "ss", as there is no
+// tile for it.
+func NormalizeWord(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ß", "ss")
+}
+
+// IsPlayableWord reports if the normalized word fits on the board and only
+// uses letters of the alphabet. Hyphenated words are not playable, as there
+// is no tile for the hyphen.
+func IsPlayableWord(word string, alphabet map[rune]bool) bool {
+	length := utf8.RuneCountInString(word)
+	if length < 2 || length > BoardSize {
+		return false
+	}
+	for _, letter := range word {
+		if !alphabet[letter] {
+			return false
+		}
+	}
+	return true
+}
@@ -247,3 +247,22 @@ func findLetterInSet(set model.LettersPlaySet, letter string) *model.LetterPlayS
 	}
 	return nil
 }
+
+func TestNormalizeWord(t *testing.T) {
+	assert.Equal(t, "übel", NormalizeWord("Übel"), "Umlauts should be lower cased")
+	assert.Equal(t, "strasse", NormalizeWord("Straße"), "'ß' should be spelled as 'ss'")
+	assert.Equal(t, "strasse", NormalizeWord("STRAẞE"), "Capital 'ẞ' should be spelled as 'ss'")
+	assert.Equal(t, "ab*", NormalizeWord("AB*"), "Wildcard should be kept")
+}
+
+func TestIsPlayableWord(t *testing.T) {
+	alphabet := AlphabetSet(Alphabet(LoadLettersPlaySet()))
+
+	assert.True(t, IsPlayableWord("übel", alphabet), "Words with umlauts should be playable")
+	assert.True(t, IsPlayableWord("öl", alphabet), "Two letter words should be playable")
+	assert.False(t, IsPlayableWord("a", alphabet), "Single letters should not be playable")
+	assert.False(t, IsPlayableWord("web-seite", alphabet), "Hyphens should not be playable")
+	assert.False(t, IsPlayableWord("straße", alphabet), "'ß' should not be playable")
+	assert.False(t, IsPlayableWord("abcdefghijklmnop", alphabet), "Words longer than the board should not be playable")
+	assert.False(t, IsPlayableWord("a*", alphabet), "Wildcard should not be part of a word")
+}
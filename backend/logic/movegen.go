@@ -0,0 +1,288 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"buchstaben.go/model"
+)
+
+// Move is a legal placement of tiles from a rack together with its score.
+type Move struct {
+	Letters   string          `json:"letters"`
+	Placement model.Placement `json:"placement"`
+	MoveScore
+}
+
+// Tiles returns the tiles the move takes from the rack, blanks as "*".
+func (m Move) Tiles() string {
+	return PlacementTiles(m.Letters, m.Placement.Blanks)
+}
+
+// lineSquare is a square of the row or column moves are generated in.
+type lineSquare struct {
+	letter rune
+	anchor bool
+	// cross holds the letters allowed by the perpendicular word, nil allows
+	// every letter.
+	cross map[rune]bool
+}
+
+type lineTile struct {
+	pos    int
+	letter rune
+	blank  bool
+}
+
+type generator struct {
+	gaddag    *Gaddag
+	grid      Grid
+	layout    Layout
+	values    map[string]uint
+	rack      map[rune]int
+	direction string
+	// index is the fixed row (horizontal) or column (vertical) of the line.
+	index  int
+	line   [BoardSize]lineSquare
+	anchor int
+	placed []lineTile
+	seen   map[string]bool
+	moves  []Move
+}
+
+// GenerateMoves returns every legal move of the rack on the board, best
+// scoring first. On an empty board only horizontal moves are generated, the
+// vertical ones are mirror images.
+func GenerateMoves(gaddag *Gaddag, board model.Board, rack string, layout Layout, values map[string]uint) []Move {
+	gen := &generator{
+		gaddag: gaddag,
+		grid:   NewGrid(board),
+		layout: layout,
+		values: values,
+		rack:   make(map[rune]int),
+		seen:   make(map[string]bool),
+	}
+	for _, letter := range strings.ToLower(rack) {
+		gen.rack[letter]++
+	}
+
+	directions := []string{model.Horizontal, model.Vertical}
+	if gen.grid.IsEmpty() {
+		directions = directions[:1]
+	}
+	for _, direction := range directions {
+		gen.direction = direction
+		for index := 0; index < BoardSize; index++ {
+			gen.index = index
+			gen.loadLine()
+			for anchor := range gen.line {
+				if gen.line[anchor].anchor {
+					gen.anchor = anchor
+					gen.place(anchor, gen.gaddag.root, true)
+				}
+			}
+		}
+	}
+
+	sort.Slice(gen.moves, func(i, j int) bool { return moveLess(gen.moves[i], gen.moves[j]) })
+	return gen.moves
+}
+
+// moveLess orders moves by points, best first. Ties are ordered by letters
+// and placement, the rack is a map and moves are found in any order.
+func moveLess(a, b Move) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+	if a.Letters != b.Letters {
+		return a.Letters < b.Letters
+	}
+	if a.Placement.Row != b.Placement.Row {
+		return a.Placement.Row < b.Placement.Row
+	}
+	if a.Placement.Col != b.Placement.Col {
+		return a.Placement.Col < b.Placement.Col
+	}
+	if a.Placement.Direction != b.Placement.Direction {
+		return a.Placement.Direction < b.Placement.Direction
+	}
+	return slices.Compare(a.Placement.Blanks, b.Placement.Blanks) < 0
+}
+
+// square returns the board coordinates of a position on the current line.
+func (gen *generator) square(pos int) (int, int) {
+	if gen.direction == model.Horizontal {
+		return gen.index, pos
+	}
+	return pos, gen.index
+}
+
+func (gen *generator) loadLine() {
+	boardEmpty := gen.grid.IsEmpty()
+	center := BoardSize / 2
+	for pos := range gen.line {
+		row, col := gen.square(pos)
+		square := lineSquare{}
+		if tile := gen.grid[row][col]; tile.Letter != "" {
+			square.letter = []rune(tile.Letter)[0]
+			gen.line[pos] = square
+			continue
+		}
+		if boardEmpty {
+			square.anchor = row == center && col == center
+			gen.line[pos] = square
+			continue
+		}
+		square.anchor = gen.grid.occupied(row-1, col) || gen.grid.occupied(row+1, col) ||
+			gen.grid.occupied(row, col-1) || gen.grid.occupied(row, col+1)
+		square.cross = gen.crossCheck(row, col)
+		gen.line[pos] = square
+	}
+}
+
+// crossCheck returns the letters which form a valid perpendicular word on
+// an empty square, or nil if the square has no perpendicular neighbours.
+func (gen *generator) crossCheck(row, col int) map[rune]bool {
+	dRow, dCol := 1, 0
+	if gen.direction == model.Vertical {
+		dRow, dCol = 0, 1
+	}
+	var prefix, suffix strings.Builder
+	start, end := 0, 0
+	for gen.grid.occupied(row-(start+1)*dRow, col-(start+1)*dCol) {
+		start++
+	}
+	for i := start; i > 0; i-- {
+		prefix.WriteString(gen.grid[row-i*dRow][col-i*dCol].Letter)
+	}
+	for gen.grid.occupied(row+(end+1)*dRow, col+(end+1)*dCol) {
+		end++
+		suffix.WriteString(gen.grid[row+end*dRow][col+end*dCol].Letter)
+	}
+	if start == 0 && end == 0 {
+		return nil
+	}
+
+	allowed := make(map[rune]bool)
+	for _, letter := range gen.gaddag.alphabet {
+		if gen.gaddag.Contains(prefix.String() + string(letter) + suffix.String()) {
+			allowed[letter] = true
+		}
+	}
+	return allowed
+}
+
+func (gen *generator) emptyOrOff(pos int) bool {
+	return pos < 0 || pos >= BoardSize || gen.line[pos].letter == 0
+}
+
+// place handles the square at pos, walking left from the anchor first and
+// right of it after the separator.
+func (gen *generator) place(pos int, node *gaddagNode, left bool) {
+	if pos < 0 || pos >= BoardSize || node == nil {
+		return
+	}
+	square := gen.line[pos]
+	if square.letter != 0 {
+		gen.next(pos, node.child(square.letter), left)
+		return
+	}
+	// moves covering another anchor on the left are generated from that one
+	if left && pos != gen.anchor && square.anchor {
+		return
+	}
+	for letter, count := range gen.rack {
+		if count == 0 {
+			continue
+		}
+		if letter == '*' {
+			for _, blankLetter := range gen.gaddag.alphabet {
+				gen.try(pos, node, left, blankLetter, true)
+			}
+			continue
+		}
+		gen.try(pos, node, left, letter, false)
+	}
+}
+
+func (gen *generator) try(pos int, node *gaddagNode, left bool, letter rune, blank bool) {
+	if cross := gen.line[pos].cross; cross != nil && !cross[letter] {
+		return
+	}
+	next := node.child(letter)
+	if next == nil {
+		return
+	}
+	tile := letter
+	if blank {
+		tile = '*'
+	}
+	gen.rack[tile]--
+	gen.placed = append(gen.placed, lineTile{pos: pos, letter: letter, blank: blank})
+	gen.next(pos, next, left)
+	gen.placed = gen.placed[:len(gen.placed)-1]
+	gen.rack[tile]++
+}
+
+func (gen *generator) next(pos int, node *gaddagNode, left bool) {
+	if node == nil {
+		return
+	}
+	if left {
+		if gen.emptyOrOff(pos - 1) {
+			if sep := node.child(separator); sep != nil {
+				if sep.terminal && gen.emptyOrOff(gen.anchor+1) {
+					gen.record()
+				}
+				gen.place(gen.anchor+1, sep, false)
+			}
+		}
+		gen.place(pos-1, node, true)
+		return
+	}
+	if node.terminal && gen.emptyOrOff(pos+1) {
+		gen.record()
+	}
+	gen.place(pos+1, node, false)
+}
+
+func (gen *generator) record() {
+	if len(gen.placed) == 0 {
+		return
+	}
+	placed := make([]lineTile, len(gen.placed))
+	copy(placed, gen.placed)
+	sort.Slice(placed, func(i, j int) bool { return placed[i].pos < placed[j].pos })
+
+	var letters strings.Builder
+	var key strings.Builder
+	tiles := make([]model.PlacedTile, 0, len(placed))
+	placement := model.Placement{Direction: gen.direction}
+	placement.Row, placement.Col = gen.square(placed[0].pos)
+	for i, t := range placed {
+		row, col := gen.square(t.pos)
+		letters.WriteRune(t.letter)
+		if t.blank {
+			placement.Blanks = append(placement.Blanks, i)
+		}
+		tiles = append(tiles, model.PlacedTile{Row: row, Col: col, Letter: string(t.letter), Blank: t.blank})
+		fmt.Fprintf(&key, "%d/%d/%c/%t;", row, col, t.letter, t.blank)
+	}
+	// a single tile is found in both directions
+	if gen.seen[key.String()] {
+		return
+	}
+	gen.seen[key.String()] = true
+
+	moveScore, err := scoreTiles(gen.grid, tiles, gen.direction, gen.layout, gen.values)
+	if err != nil {
+		return
+	}
+	gen.moves = append(gen.moves, Move{
+		Letters:   letters.String(),
+		Placement: placement,
+		MoveScore: moveScore,
+	})
+}
@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMoves(t *testing.T) {
+	words := []string{"hut", "huts", "ah", "us", "es", "he", "mut", "tut", "sah"}
+	gaddag := NewGaddag(words, Alphabet(LoadLettersPlaySet()))
+	values := LetterValues(LoadLettersPlaySet())
+	layout := LoadStandardLayout()
+	hut := model.Board{
+		{Row: 7, Col: 6, Letter: "h"},
+		{Row: 7, Col: 7, Letter: "u"},
+		{Row: 7, Col: 8, Letter: "t"},
+	}
+
+	t.Run("Empty board", func(t *testing.T) {
+		moves := GenerateMoves(gaddag, model.Board{}, "thu", layout, values)
+
+		assert.Len(t, moves, 3, "Word 'hut' should be placeable in three positions over the center")
+		for i, move := range moves {
+			assert.Equal(t, "hut", move.Letters)
+			assert.Equal(t, model.Horizontal, move.Placement.Direction)
+			assert.Equal(t, uint(4), move.Points)
+			assert.Equal(t, 5+i, move.Placement.Col, "Equal moves should be ordered by their position")
+		}
+	})
+
+	t.Run("Extend existing word", func(t *testing.T) {
+		moves := GenerateMoves(gaddag, hut, "s", layout, values)
+
+		assert.NotEmpty(t, moves)
+		assert.Equal(t, "s", moves[0].Letters)
+		assert.Equal(t, []string{"huts"}, moves[0].WordList(), "Best move should extend 'hut'")
+		assert.Equal(t, uint(5), moves[0].Points)
+	})
+
+	t.Run("Blank", func(t *testing.T) {
+		moves := GenerateMoves(gaddag, hut, "*", layout, values)
+
+		found := false
+		for _, move := range moves {
+			if move.Placement.Row == 7 && move.Placement.Col == 9 && move.Letters == "s" {
+				found = true
+				assert.Equal(t, []int{0}, move.Placement.Blanks, "Blank should be reported")
+				assert.Equal(t, uint(4), move.Points, "Blank should score zero")
+				assert.Equal(t, "*", move.Tiles())
+			}
+		}
+		assert.True(t, found, "Blank should be used as 's'")
+	})
+
+	t.Run("Every move is valid", func(t *testing.T) {
+		dictionary := make(map[string]bool)
+		for _, word := range words {
+			dictionary[word] = true
+		}
+		moves := GenerateMoves(gaddag, hut, "sahemt", layout, values)
+
+		assert.NotEmpty(t, moves)
+		for i, move := range moves {
+			score, err := ScoreMove(hut, move.Placement, move.Letters, layout, values)
+			assert.NoError(t, err, "Move %+v should be placeable", move)
+			assert.Equal(t, score.Points, move.Points, "Move %+v should be scored correctly", move)
+			for _, word := range move.WordList() {
+				assert.True(t, dictionary[word], "Word %q of move %+v should be in the dictionary", word, move)
+			}
+			if i > 0 {
+				assert.GreaterOrEqual(t, moves[i-1].Points, move.Points, "Moves should be sorted by points")
+			}
+		}
+		for range 10 {
+			assert.Equal(t, moves, GenerateMoves(gaddag, hut, "sahemt", layout, values), "Moves should always come out in the same order")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package logic
+
+import (
+	"fmt"
+
+	"buchstaben.go/model"
+)
+
+// AddLetters is the inverse of RemoveLetters, it puts the tiles back into a
+// copy of the set.
+func AddLetters(lettersPlaySet model.LettersPlaySet, inputString string) (model.LettersPlaySet, error) {
+	updated := make(model.LettersPlaySet, len(lettersPlaySet))
+	copy(updated, lettersPlaySet)
+	for _, letter := range inputString {
+		isValidLetter := false
+		for i, l := range updated {
+			if l.Letter == string(letter) {
+				if updated[i].CurrentCount >= updated[i].OriginalCount {
+					return lettersPlaySet, fmt.Errorf("letter %q cannot be returned, all %d tiles are left already", l.Letter, l.OriginalCount)
+				}
+				updated[i].CurrentCount++
+				isValidLetter = true
+				break
+			}
+		}
+		if !isValidLetter {
+			return lettersPlaySet, fmt.Errorf("letter %q is not valid", string(letter))
+		}
+	}
+	return updated, nil
+}
+
+// MoveTiles returns the tiles a move took from the letter set, letters
+// played as blank are "*".
+func MoveTiles(move model.PlayedMove) string {
+	if move.Placement == nil {
+		return move.Letters
+	}
+	return PlacementTiles(move.Letters, move.Placement.Blanks)
+}
+
+// RebuildBoard places the tiles of all moves with a placement in order on
+// an empty board.
+func RebuildBoard(moves []model.PlayedMove) (model.Board, error) {
+	board := model.Board{}
+	for i, move := range moves {
+		if move.Placement == nil {
+			continue
+		}
+		var err error
+		board, err = PlaceTiles(board, *move.Placement, move.Letters)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i, err)
+		}
+	}
+	return board, nil
+}
+
+// ReplaceMove rebuilds the board with the move at index replaced, or removed
+// if move is nil. The later moves are scored again on the rebuilt board and
+// get their points and words updated, each of them has to place its tiles on
+// the same squares as before.
+func ReplaceMove(moves []model.PlayedMove, index int, move *model.PlayedMove, layout Layout, values map[string]uint) (model.Board, []model.PlayedMove, error) {
+	placed, err := placedTiles(moves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := copyMoves(moves)
+	later := updated[index+1:]
+	if move == nil {
+		updated = append(updated[:index], later...)
+		later = updated[index:]
+	} else {
+		updated[index] = *move
+	}
+	board, err := RebuildBoard(updated[:len(updated)-len(later)])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range later {
+		original := index + 1 + i
+		if later[i].Placement == nil {
+			continue
+		}
+		tiles, err := NewTiles(board, *later[i].Placement, later[i].Letters)
+		if err != nil {
+			return nil, nil, fmt.Errorf("move %d: %w", original, err)
+		}
+		if !sameTiles(tiles, placed[original]) {
+			return nil, nil, fmt.Errorf("move %d would place its tiles on other squares", original)
+		}
+		moveScore, err := ScoreMove(board, *later[i].Placement, later[i].Letters, layout, values)
+		if err != nil {
+			return nil, nil, fmt.Errorf("move %d: %w", original, err)
+		}
+		later[i].Points = moveScore.Points
+		later[i].Words = moveScore.WordList()
+		board = append(board, tiles...)
+	}
+	return board, updated, nil
+}
+
+// placedTiles returns the new tiles of every move, none for moves without a
+// placement.
+func placedTiles(moves []model.PlayedMove) ([][]model.PlacedTile, error) {
+	board := model.Board{}
+	placed := make([][]model.PlacedTile, len(moves))
+	for i, move := range moves {
+		if move.Placement == nil {
+			continue
+		}
+		tiles, err := NewTiles(board, *move.Placement, move.Letters)
+		if err != nil {
+			return nil, fmt.Errorf("move %d: %w", i, err)
+		}
+		placed[i] = tiles
+		board = append(board, tiles...)
+	}
+	return placed, nil
+}
+
+func sameTiles(a, b []model.PlacedTile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
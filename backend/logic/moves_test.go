@@ -0,0 +1,85 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddLetters(t *testing.T) {
+	lettersPlaySet := LoadLettersPlaySet()
+	played, err := RemoveLetters(LoadLettersPlaySet(), "ab*")
+	assert.NoError(t, err)
+
+	restored, err := AddLetters(played, "*ba")
+	assert.NoError(t, err)
+	assert.Equal(t, lettersPlaySet, restored, "Expected AddLetters to undo RemoveLetters")
+
+	_, err = AddLetters(restored, "a")
+	assert.Error(t, err, "Expected error for more tiles than the set has")
+
+	unchanged := LoadLettersPlaySet()
+	unchanged[0].CurrentCount--
+	_, err = AddLetters(unchanged, "a1")
+	assert.Error(t, err, "Expected error for invalid letter")
+	assert.Equal(t, unchanged[0].OriginalCount-1, unchanged[0].CurrentCount, "Expected the set not to be modified")
+}
+
+func TestMoveTiles(t *testing.T) {
+	assert.Equal(t, "abc", MoveTiles(model.PlayedMove{Letters: "abc"}))
+	assert.Equal(t, "a*c", MoveTiles(model.PlayedMove{Letters: "abc", Placement: &model.Placement{Blanks: []int{1}}}))
+}
+
+func TestRebuildBoard(t *testing.T) {
+	moves := []model.PlayedMove{
+		{Letters: "hut", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}},
+		{Letters: "xy"},
+		{Letters: "s", Placement: &model.Placement{Row: 7, Col: 9, Direction: model.Horizontal}},
+	}
+
+	board, err := RebuildBoard(moves)
+	assert.NoError(t, err)
+	assert.Len(t, board, 4)
+	assert.Equal(t, model.PlacedTile{Row: 7, Col: 9, Letter: "s"}, board[3])
+
+	_, err = RebuildBoard(moves[2:])
+	assert.Error(t, err, "Expected error for a move not covering the center")
+	assert.Contains(t, err.Error(), "move 0")
+}
+
+func TestReplaceMove(t *testing.T) {
+	layout := LoadStandardLayout()
+	values := LetterValues(LoadLettersPlaySet())
+	moves := []model.PlayedMove{
+		{Letters: "hut", Words: []string{"hut"}, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}},
+		{Letters: "xy"},
+		// plays through the u of hut
+		{Letters: "tt", Words: []string{"tut"}, Points: 3, Placement: &model.Placement{Row: 6, Col: 7, Direction: model.Vertical}},
+	}
+
+	hot := model.PlayedMove{Letters: "hot", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}}
+	board, updated, err := ReplaceMove(moves, 0, &hot, layout, values)
+	assert.NoError(t, err)
+	assert.Len(t, board, 5)
+	assert.Equal(t, hot, updated[0])
+	assert.Equal(t, moves[1], updated[1])
+	expected, _ := ScoreMove(model.Board{{Row: 7, Col: 6, Letter: "h"}, {Row: 7, Col: 7, Letter: "o"}, {Row: 7, Col: 8, Letter: "t"}},
+		*moves[2].Placement, "tt", layout, values)
+	assert.Equal(t, []string{"tot"}, updated[2].Words, "Expected the later move to be scored again")
+	assert.Equal(t, expected.Points, updated[2].Points)
+	assert.Equal(t, []string{"tut"}, moves[2].Words, "Expected the moves not to be modified")
+
+	board, updated, err = ReplaceMove(moves, 1, nil, layout, values)
+	assert.NoError(t, err)
+	assert.Len(t, board, 5)
+	assert.Len(t, updated, 2)
+	assert.Equal(t, "tt", updated[1].Letters)
+
+	_, _, err = ReplaceMove(moves, 0, nil, layout, values)
+	assert.EqualError(t, err, "move 2 would place its tiles on other squares")
+
+	ut := model.PlayedMove{Letters: "ut", Placement: &model.Placement{Row: 7, Col: 7, Direction: model.Vertical}}
+	_, _, err = ReplaceMove(moves, 0, &ut, layout, values)
+	assert.EqualError(t, err, "move 2 would place its tiles on other squares", "Expected error if the later move would slide into a freed square")
+}
@@ -0,0 +1,85 @@
+package logic
+
+import (
+	"sort"
+	"strings"
+
+	"buchstaben.go/model"
+)
+
+// LetterHolding is the guess how many tiles of a letter the opponent holds.
+type LetterHolding struct {
+	Letter string `json:"letter"`
+	Unseen uint   `json:"unseen"`
+	// Expected is the average number of the tiles on the opponent's rack
+	Expected float64 `json:"expected"`
+	// Probability is the chance the opponent holds at least one of them
+	Probability float64 `json:"probability"`
+}
+
+// OpponentRack is what the unseen tiles tell about the opponent's rack. Once
+// no more than a full rack is unseen, the bag is empty and the rack is known.
+type OpponentRack struct {
+	Exact  bool   `json:"exact"`
+	Unseen uint   `json:"unseen"`
+	InBag  uint   `json:"in_bag"`
+	Rack   string `json:"rack,omitempty"`
+	// Letters is ordered by the expected number of tiles, most likely first
+	Letters []LetterHolding `json:"letters"`
+}
+
+// InferOpponentRack guesses the opponent's rack out of the unseen tiles,
+// assuming the opponent holds a full rack as long as tiles are left. The
+// unseen tiles must not hold my rack, else my tiles would be taken for the
+// opponent's.
+func InferOpponentRack(unseen model.LettersPlaySet) OpponentRack {
+	total := GetRemindingsLetterCount(unseen)
+	rackSize := uint(RackSize)
+	if total < rackSize {
+		rackSize = total
+	}
+	opponent := OpponentRack{
+		Exact:   total <= RackSize,
+		Unseen:  total,
+		InBag:   total - rackSize,
+		Letters: []LetterHolding{},
+	}
+
+	var rack strings.Builder
+	for _, l := range unseen {
+		if l.CurrentCount == 0 {
+			continue
+		}
+		holding := LetterHolding{Letter: l.Letter, Unseen: l.CurrentCount}
+		if opponent.Exact {
+			rack.WriteString(strings.Repeat(l.Letter, int(l.CurrentCount)))
+			holding.Expected = float64(l.CurrentCount)
+			holding.Probability = 1
+		} else {
+			holding.Expected = float64(l.CurrentCount) * float64(rackSize) / float64(total)
+			holding.Probability = atLeastOne(total, l.CurrentCount, rackSize)
+		}
+		opponent.Letters = append(opponent.Letters, holding)
+	}
+	opponent.Rack = rack.String()
+
+	sort.SliceStable(opponent.Letters, func(i, j int) bool {
+		return opponent.Letters[i].Expected > opponent.Letters[j].Expected
+	})
+	return opponent
+}
+
+// RemoveFromRack returns the rack without the tiles, tiles not on the rack
+// are ignored.
+func RemoveFromRack(rack, tiles string) string {
+	remaining := []rune(strings.ToLower(rack))
+	for _, tile := range strings.ToLower(tiles) {
+		for i, letter := range remaining {
+			if letter == tile {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	return string(remaining)
+}
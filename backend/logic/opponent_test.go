@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"buchstaben.go/model"
+)
+
+func TestInferOpponentRack(t *testing.T) {
+	unseen := model.LettersPlaySet{
+		{Letter: "a", CurrentCount: 2, Value: 1},
+		{Letter: "b", CurrentCount: 0, Value: 3},
+		{Letter: "e", CurrentCount: 3, Value: 1},
+		{Letter: "*", CurrentCount: 1, Value: 0},
+	}
+
+	opponent := InferOpponentRack(unseen)
+	assert.True(t, opponent.Exact, "Expected the rack to be known with an empty bag")
+	assert.Equal(t, uint(0), opponent.InBag)
+	assert.Equal(t, "aaeee*", opponent.Rack)
+	assert.Len(t, opponent.Letters, 3, "Expected letters not unseen to be left out")
+	assert.Equal(t, "e", opponent.Letters[0].Letter)
+	assert.Equal(t, 1.0, opponent.Letters[0].Probability)
+
+	unseen[1].CurrentCount = 8
+	opponent = InferOpponentRack(unseen)
+	assert.False(t, opponent.Exact)
+	assert.Equal(t, uint(14), opponent.Unseen)
+	assert.Equal(t, uint(7), opponent.InBag)
+	assert.Empty(t, opponent.Rack)
+	assert.Equal(t, "b", opponent.Letters[0].Letter, "Expected the most likely letter first")
+	assert.InDelta(t, 4.0, opponent.Letters[0].Expected, 1e-9)
+	assert.InDelta(t, 0.5, opponent.Letters[3].Probability, 1e-9, "Expected the blank to be held half of the time")
+}
+
+func TestRemoveFromRack(t *testing.T) {
+	assert.Equal(t, "aeö", RemoveFromRack("haeöt", "th"))
+	assert.Equal(t, "a", RemoveFromRack("a*", "*"))
+	assert.Equal(t, "ab", RemoveFromRack("AB", "x"), "Expected tiles not on the rack to be ignored")
+}
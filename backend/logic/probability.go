@@ -0,0 +1,105 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"buchstaben.go/model"
+)
+
+// LetterOdds is the chance to draw a letter at least once.
+type LetterOdds struct {
+	Letter      string  `json:"letter"`
+	Unseen      uint    `json:"unseen"`
+	Value       uint    `json:"value"`
+	Probability float64 `json:"probability"`
+}
+
+// DrawOdds describes the next draws out of the unseen tiles. Draws are
+// limited to the tiles in the bag, the unseen tiles less the opponent's rack.
+type DrawOdds struct {
+	Unseen  uint         `json:"unseen"`
+	Bag     uint         `json:"bag"`
+	Draws   int          `json:"draws"`
+	Letters []LetterOdds `json:"letters"`
+	// Blank is the chance to draw at least one blank
+	Blank float64 `json:"blank"`
+	// ExpectedValue is the average value of the next tile drawn
+	ExpectedValue float64 `json:"expected_value"`
+}
+
+// UnseenTiles returns a copy of the set without the tiles of the rack. These
+// are the tiles in the bag and on the opponent's rack.
+func UnseenTiles(lettersPlaySet model.LettersPlaySet, rack string) (model.LettersPlaySet, error) {
+	unseen := make(model.LettersPlaySet, len(lettersPlaySet))
+	copy(unseen, lettersPlaySet)
+	for _, letter := range strings.ToLower(rack) {
+		found := false
+		for i, l := range unseen {
+			if l.Letter != string(letter) {
+				continue
+			}
+			if unseen[i].CurrentCount == 0 {
+				return nil, fmt.Errorf("rack letter %q is not available anymore", l.Letter)
+			}
+			unseen[i].CurrentCount--
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("rack letter %q is not valid", string(letter))
+		}
+	}
+	return unseen, nil
+}
+
+// DrawProbabilities calculates the chance of every letter to be drawn at
+// least once in the next draws. Every unseen tile is as likely to be in the
+// bag as on the opponent's rack, but no more tiles can be drawn than the bag
+// holds.
+func DrawProbabilities(unseen model.LettersPlaySet, draws int) DrawOdds {
+	total := GetRemindingsLetterCount(unseen)
+	bag := uint(0)
+	if total > RackSize {
+		bag = total - RackSize
+	}
+	if draws < 0 {
+		draws = 0
+	}
+	if uint(draws) > bag {
+		draws = int(bag)
+	}
+	odds := DrawOdds{Unseen: total, Bag: bag, Draws: draws, Letters: make([]LetterOdds, 0, len(unseen))}
+	for _, l := range unseen {
+		probability := atLeastOne(total, l.CurrentCount, uint(draws))
+		odds.Letters = append(odds.Letters, LetterOdds{
+			Letter:      l.Letter,
+			Unseen:      l.CurrentCount,
+			Value:       l.Value,
+			Probability: probability,
+		})
+		if l.Letter == "*" {
+			odds.Blank = probability
+		}
+	}
+	if total > 0 {
+		odds.ExpectedValue = float64(GetLetterValue(unseen)) / float64(total)
+	}
+	return odds
+}
+
+// atLeastOne returns the hypergeometric chance to draw at least one of count
+// tiles out of total in draws, 1 - C(total-count, draws) / C(total, draws).
+func atLeastOne(total, count, draws uint) float64 {
+	if count == 0 || draws == 0 {
+		return 0
+	}
+	if total-count < draws {
+		return 1
+	}
+	none := 1.0
+	for i := uint(0); i < draws; i++ {
+		none *= float64(total-count-i) / float64(total-i)
+	}
+	return 1 - none
+}
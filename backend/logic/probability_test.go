@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"buchstaben.go/model"
+)
+
+func TestUnseenTiles(t *testing.T) {
+	lettersPlaySet := LoadLettersPlaySet()
+
+	unseen, err := UnseenTiles(lettersPlaySet, "aA*")
+	assert.NoError(t, err)
+	assert.Equal(t, GetRemindingsLetterCount(lettersPlaySet)-3, GetRemindingsLetterCount(unseen))
+	assert.Equal(t, GetRemindingsLetterCount(LoadLettersPlaySet()), GetRemindingsLetterCount(lettersPlaySet),
+		"Expected the set not to be modified")
+
+	_, err = UnseenTiles(lettersPlaySet, "1")
+	assert.Error(t, err, "Expected error for invalid letter")
+
+	_, err = UnseenTiles(lettersPlaySet, "qq")
+	assert.Error(t, err, "Expected error for letter not available anymore")
+}
+
+func TestDrawProbabilities(t *testing.T) {
+	unseen := model.LettersPlaySet{
+		{Letter: "a", CurrentCount: 6, Value: 1},
+		{Letter: "b", CurrentCount: 3, Value: 3},
+		{Letter: "c", CurrentCount: 0, Value: 4},
+		{Letter: "*", CurrentCount: 1, Value: 0},
+	}
+
+	odds := DrawProbabilities(unseen, 2)
+	assert.Equal(t, uint(10), odds.Unseen)
+	assert.Equal(t, uint(3), odds.Bag, "Expected the opponent's rack not to be in the bag")
+	assert.Equal(t, 2, odds.Draws)
+	// 1 - C(4,2)/C(10,2) = 1 - 6/45
+	assert.InDelta(t, 39.0/45, odds.Letters[0].Probability, 1e-9)
+	// 1 - C(7,2)/C(10,2) = 1 - 21/45
+	assert.InDelta(t, 24.0/45, odds.Letters[1].Probability, 1e-9)
+	assert.Equal(t, 0.0, odds.Letters[2].Probability)
+	// 1 - C(9,2)/C(10,2) = 1 - 36/45
+	assert.InDelta(t, 9.0/45, odds.Blank, 1e-9)
+	assert.InDelta(t, 15.0/10, odds.ExpectedValue, 1e-9)
+
+	odds = DrawProbabilities(unseen, 10)
+	assert.Equal(t, 3, odds.Draws, "Expected the draws to be limited to the tiles in the bag")
+	// 1 - C(7,3)/C(10,3) = 1 - 35/120
+	assert.InDelta(t, 85.0/120, odds.Letters[1].Probability, 1e-9)
+
+	odds = DrawProbabilities(unseen[1:], 1)
+	assert.Equal(t, uint(0), odds.Bag, "Expected an empty bag with the opponent's rack left")
+	assert.Equal(t, 0, odds.Draws)
+	assert.Equal(t, 0.0, odds.Letters[0].Probability)
+
+	odds = DrawProbabilities(model.LettersPlaySet{{Letter: "a", CurrentCount: 0, Value: 1}}, 1)
+	assert.Equal(t, 0, odds.Draws)
+	assert.Equal(t, 0.0, odds.ExpectedValue)
+}
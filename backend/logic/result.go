@@ -0,0 +1,73 @@
+package logic
+
+import "buchstaben.go/model"
+
+// Scores totals the points of the moves for me and the opponent.
+func Scores(playedMoves []model.PlayedMove) (int, int) {
+	mine, opponent := 0, 0
+	for _, move := range playedMoves {
+		if move.PlayedByMyself {
+			mine += int(move.Points)
+		} else {
+			opponent += int(move.Points)
+		}
+	}
+	return mine, opponent
+}
+
+// FinalResult ends the game with my rack, an empty one if I played out and
+// nil if it is not known. Once the bag is empty the tiles left on the racks
+// count: the player who played out wins the value of the other rack, which
+// the other player loses. If nobody played out, both lose the value of their
+// own rack. Games ended before the bag was empty or without my rack keep the
+// scores of their moves.
+func FinalResult(game model.UserGame, rack *string) (model.GameResult, error) {
+	mine, opponent := Scores(game.PlayedMoves)
+	result := model.GameResult{}
+	if rack == nil {
+		return scoredResult(result, mine, opponent), nil
+	}
+	unseen, err := UnseenTiles(game.LettersPlaySet, *rack)
+	if err != nil {
+		return model.GameResult{}, err
+	}
+
+	if GetRemindingsLetterCount(unseen) <= RackSize {
+		// the unseen tiles are the opponent's rack
+		opponentValue := int(GetLetterValue(unseen))
+		myValue := int(GetLetterValue(game.LettersPlaySet)) - opponentValue
+		switch {
+		case *rack == "":
+			result.MyRackAdjustment = opponentValue
+			result.OpponentRackAdjustment = -opponentValue
+		case GetRemindingsLetterCount(unseen) == 0:
+			result.MyRackAdjustment = -myValue
+			result.OpponentRackAdjustment = myValue
+		default:
+			result.MyRackAdjustment = -myValue
+			result.OpponentRackAdjustment = -opponentValue
+		}
+	}
+
+	return scoredResult(result, mine, opponent), nil
+}
+
+// scoredResult adds the points of the moves to the rack adjustments.
+func scoredResult(result model.GameResult, mine, opponent int) model.GameResult {
+	result.MyScore = mine + result.MyRackAdjustment
+	result.OpponentScore = opponent + result.OpponentRackAdjustment
+	result.Spread = result.MyScore - result.OpponentScore
+	result.Outcome = Outcome(result.Spread)
+	return result
+}
+
+// Outcome returns whether I won, lost or drew with the spread.
+func Outcome(spread int) string {
+	switch {
+	case spread > 0:
+		return model.OutcomeWon
+	case spread < 0:
+		return model.OutcomeLost
+	}
+	return model.OutcomeDraw
+}
@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScores(t *testing.T) {
+	mine, opponent := Scores([]model.PlayedMove{
+		{Points: 10, PlayedByMyself: true},
+		{Points: 20},
+		{Points: 5, PlayedByMyself: true},
+	})
+	assert.Equal(t, 15, mine)
+	assert.Equal(t, 20, opponent)
+
+	mine, opponent = Scores(nil)
+	assert.Zero(t, mine)
+	assert.Zero(t, opponent)
+}
+
+func TestFinalResult(t *testing.T) {
+	game := model.UserGame{
+		LettersPlaySet: model.LettersPlaySet{
+			{Letter: "a", OriginalCount: 5, CurrentCount: 2, Value: 1},
+			{Letter: "q", OriginalCount: 1, CurrentCount: 1, Value: 10},
+		},
+		PlayedMoves: []model.PlayedMove{
+			{Points: 30, PlayedByMyself: true},
+			{Points: 20},
+		},
+	}
+
+	rack := func(rack string) *string { return &rack }
+	tests := []struct {
+		name     string
+		rack     *string
+		expected model.GameResult
+	}{
+		{"I played out", rack(""), model.GameResult{MyScore: 42, OpponentScore: 8, Spread: 34, Outcome: model.OutcomeWon, MyRackAdjustment: 12, OpponentRackAdjustment: -12}},
+		{"opponent played out", rack("aaq"), model.GameResult{MyScore: 18, OpponentScore: 32, Spread: -14, Outcome: model.OutcomeLost, MyRackAdjustment: -12, OpponentRackAdjustment: 12}},
+		{"nobody played out", rack("aq"), model.GameResult{MyScore: 19, OpponentScore: 19, Spread: 0, Outcome: model.OutcomeDraw, MyRackAdjustment: -11, OpponentRackAdjustment: -1}},
+		{"rack unknown", nil, model.GameResult{MyScore: 30, OpponentScore: 20, Spread: 10, Outcome: model.OutcomeWon}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FinalResult(game, tt.rack)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	game.LettersPlaySet = LoadLettersPlaySet()
+	result, err := FinalResult(game, rack("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, model.GameResult{MyScore: 30, OpponentScore: 20, Spread: 10, Outcome: model.OutcomeWon}, result, "Expected no rack adjustments with tiles in the bag")
+
+	_, err = FinalResult(game, rack("1"))
+	assert.Error(t, err, "Expected error for an invalid rack")
+}
@@ -0,0 +1,125 @@
+package logic
+
+import (
+	"fmt"
+	"strings"
+
+	"buchstaben.go/model"
+)
+
+// BingoBonus is awarded when all seven tiles of a rack are placed in one move.
+const BingoBonus = 40
+
+const RackSize = 7
+
+type WordScore struct {
+	Word   string `json:"word"`
+	Points uint   `json:"points"`
+}
+
+type MoveScore struct {
+	Words  []WordScore `json:"words"`
+	Bingo  bool        `json:"bingo"`
+	Points uint        `json:"points"`
+}
+
+// WordList returns the words formed by the move, main word first.
+func (ms MoveScore) WordList() []string {
+	words := make([]string, 0, len(ms.Words))
+	for _, w := range ms.Words {
+		words = append(words, w.Word)
+	}
+	return words
+}
+
+// LetterValues maps every letter of the set to its value.
+func LetterValues(lettersPlaySet model.LettersPlaySet) map[string]uint {
+	values := make(map[string]uint, len(lettersPlaySet))
+	for _, l := range lettersPlaySet {
+		values[l.Letter] = l.Value
+	}
+	return values
+}
+
+// ScoreMove calculates the points of a placement on the board: the main word,
+// every cross word and the bingo bonus. The board itself is not modified.
+func ScoreMove(board model.Board, placement model.Placement, letters string, layout Layout, values map[string]uint) (MoveScore, error) {
+	newTiles, err := NewTiles(board, placement, letters)
+	if err != nil {
+		return MoveScore{}, err
+	}
+	return scoreTiles(NewGrid(board), newTiles, placement.Direction, layout, values)
+}
+
+func scoreTiles(grid Grid, newTiles []model.PlacedTile, direction string, layout Layout, values map[string]uint) (MoveScore, error) {
+	dRow, dCol, err := step(direction)
+	if err != nil {
+		return MoveScore{}, err
+	}
+	isNew := make(map[[2]int]bool, len(newTiles))
+	for _, tile := range newTiles {
+		grid[tile.Row][tile.Col] = tile
+		isNew[[2]int{tile.Row, tile.Col}] = true
+	}
+
+	moveScore := MoveScore{}
+	if word, ok := scoreWord(&grid, newTiles[0].Row, newTiles[0].Col, dRow, dCol, isNew, layout, values); ok {
+		moveScore.Words = append(moveScore.Words, word)
+	}
+	for _, tile := range newTiles {
+		if word, ok := scoreWord(&grid, tile.Row, tile.Col, dCol, dRow, isNew, layout, values); ok {
+			moveScore.Words = append(moveScore.Words, word)
+		}
+	}
+	if len(moveScore.Words) == 0 {
+		return MoveScore{}, fmt.Errorf("the move does not form a word")
+	}
+
+	for _, word := range moveScore.Words {
+		moveScore.Points += word.Points
+	}
+	if len(newTiles) == RackSize {
+		moveScore.Bingo = true
+		moveScore.Points += BingoBonus
+	}
+	return moveScore, nil
+}
+
+// scoreWord scores the word running through row/col in the given direction.
+// Words shorter than two letters are not reported.
+func scoreWord(grid *Grid, row, col, dRow, dCol int, isNew map[[2]int]bool, layout Layout, values map[string]uint) (WordScore, bool) {
+	for grid.occupied(row-dRow, col-dCol) {
+		row, col = row-dRow, col-dCol
+	}
+
+	var word strings.Builder
+	length := 0
+	sum, multiplier := uint(0), uint(1)
+	for ; grid.occupied(row, col); row, col = row+dRow, col+dCol {
+		tile := grid[row][col]
+		word.WriteString(tile.Letter)
+		length++
+
+		value := values[tile.Letter]
+		if tile.Blank {
+			value = 0
+		}
+		if isNew[[2]int{row, col}] {
+			switch layout[row][col] {
+			case DoubleLetter:
+				value *= 2
+			case TripleLetter:
+				value *= 3
+			case DoubleWord:
+				multiplier *= 2
+			case TripleWord:
+				multiplier *= 3
+			}
+		}
+		sum += value
+	}
+	if length < 2 {
+		return WordScore{}, false
+	}
+	return WordScore{Word: word.String(), Points: sum * multiplier}, true
+}
@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreMove(t *testing.T) {
+	values := LetterValues(LoadLettersPlaySet())
+	layout := LoadStandardLayout()
+	hut := model.Board{
+		{Row: 7, Col: 6, Letter: "h"},
+		{Row: 7, Col: 7, Letter: "u"},
+		{Row: 7, Col: 8, Letter: "t"},
+	}
+
+	testCases := []struct {
+		name           string
+		board          model.Board
+		placement      model.Placement
+		letters        string
+		expectedWords  []WordScore
+		expectedBingo  bool
+		expectedPoints uint
+		errorContains  string
+	}{
+		{
+			name:           "First move without premium",
+			board:          model.Board{},
+			placement:      model.Placement{Row: 7, Col: 6, Direction: model.Horizontal},
+			letters:        "hut",
+			expectedWords:  []WordScore{{Word: "hut", Points: 4}},
+			expectedPoints: 4,
+		},
+		{
+			name:           "Double word",
+			board:          model.Board{},
+			placement:      model.Placement{Row: 7, Col: 3, Direction: model.Horizontal},
+			letters:        "quirl",
+			expectedWords:  []WordScore{{Word: "quirl", Points: 30}},
+			expectedPoints: 30, // (10 + 1 + 1 + 1 + 2) * 2
+		},
+		{
+			name:           "Blank scores zero",
+			board:          model.Board{},
+			placement:      model.Placement{Row: 7, Col: 3, Direction: model.Horizontal, Blanks: []int{0}},
+			letters:        "quirl",
+			expectedWords:  []WordScore{{Word: "quirl", Points: 10}},
+			expectedPoints: 10, // (0 + 1 + 1 + 1 + 2) * 2
+		},
+		{
+			name:           "Extend an existing word",
+			board:          hut,
+			placement:      model.Placement{Row: 7, Col: 9, Direction: model.Horizontal},
+			letters:        "s",
+			expectedWords:  []WordScore{{Word: "huts", Points: 5}},
+			expectedPoints: 5,
+		},
+		{
+			name:           "Single tile forming a perpendicular word",
+			board:          hut,
+			placement:      model.Placement{Row: 6, Col: 6, Direction: model.Horizontal},
+			letters:        "a",
+			expectedWords:  []WordScore{{Word: "ah", Points: 3}},
+			expectedPoints: 3,
+		},
+		{
+			name:           "Through an existing tile",
+			board:          hut,
+			placement:      model.Placement{Row: 6, Col: 7, Direction: model.Vertical},
+			letters:        "mt",
+			expectedWords:  []WordScore{{Word: "mut", Points: 5}},
+			expectedPoints: 5,
+		},
+		{
+			name:      "Cross words",
+			board:     hut,
+			placement: model.Placement{Row: 8, Col: 6, Direction: model.Horizontal},
+			letters:   "es",
+			expectedWords: []WordScore{
+				{Word: "es", Points: 2},
+				{Word: "he", Points: 3},
+				{Word: "us", Points: 2},
+			},
+			expectedPoints: 7,
+		},
+		{
+			name:           "Bingo",
+			board:          model.Board{},
+			placement:      model.Placement{Row: 7, Col: 1, Direction: model.Horizontal},
+			letters:        "abcdefg",
+			expectedWords:  []WordScore{{Word: "abcdefg", Points: 30}},
+			expectedBingo:  true,
+			expectedPoints: 70, // (1 + 2 + 4 + 1 + 1 + 4 + 2) * 2 + 40
+		},
+		{
+			name:          "Single letter on an empty board",
+			board:         model.Board{},
+			placement:     model.Placement{Row: 7, Col: 7, Direction: model.Horizontal},
+			letters:       "a",
+			errorContains: "does not form a word",
+		},
+		{
+			name:          "Invalid placement",
+			board:         hut,
+			placement:     model.Placement{Row: 0, Col: 0, Direction: model.Horizontal},
+			letters:       "ab",
+			errorContains: "not connected",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ScoreMove(tc.board, tc.placement, tc.letters, layout, values)
+
+			if tc.errorContains != "" {
+				assert.Error(t, err, "Should return an error")
+				assert.Contains(t, err.Error(), tc.errorContains, "Error should contain expected text")
+				return
+			}
+			assert.NoError(t, err, "Should not return an error")
+			assert.Equal(t, tc.expectedWords, result.Words, "Words should match")
+			assert.Equal(t, tc.expectedBingo, result.Bingo, "Bingo should match")
+			assert.Equal(t, tc.expectedPoints, result.Points, "Points should match")
+		})
+	}
+}
+
+func TestLetterValues(t *testing.T) {
+	values := LetterValues(LoadLettersPlaySet())
+
+	assert.Equal(t, uint(1), values["a"], "Letter 'a' should have value 1")
+	assert.Equal(t, uint(10), values["q"], "Letter 'q' should have value 10")
+	assert.Equal(t, uint(0), values["*"], "Wildcard should have value 0")
+}
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"time"
+
+	"buchstaben.go/model"
+)
+
+// IsBingo reports if the move placed all tiles of a full rack.
+func IsBingo(move model.PlayedMove) bool {
+	return len([]rune(MoveTiles(move))) == RackSize
+}
+
+// GameResult returns the stored result of an ended game, games ended before
+// results were kept get one out of the points of their moves. The result is
+// not known for such games without any move of mine, as only the opponent's
+// moves were recorded back then.
+func GameResult(game model.UserGame) (model.GameResult, bool) {
+	if game.Result != nil {
+		return *game.Result, true
+	}
+	if !HasOwnMoves(game.PlayedMoves) {
+		return model.GameResult{}, false
+	}
+	mine, opponent := Scores(game.PlayedMoves)
+	return model.GameResult{
+		MyScore:       mine,
+		OpponentScore: opponent,
+		Spread:        mine - opponent,
+		Outcome:       Outcome(mine - opponent),
+	}, true
+}
+
+// HasOwnMoves reports if any of the moves was played by myself.
+func HasOwnMoves(playedMoves []model.PlayedMove) bool {
+	for _, move := range playedMoves {
+		if move.PlayedByMyself {
+			return true
+		}
+	}
+	return false
+}
+
+// OpponentStatistics sums up the active and ended games against the user.
+// Ended games without a valid start or end timestamp are left out of the
+// durations, ended games without a known result out of the outcomes and
+// averages.
+func OpponentStatistics(user string, active, ended []model.UserGame) model.OpponentStats {
+	stats := model.OpponentStats{User: user, Games: len(ended), ActiveGames: len(active)}
+
+	for _, game := range append(active[:len(active):len(active)], ended...) {
+		for _, move := range game.PlayedMoves {
+			move := move
+			if move.PlayedByMyself {
+				stats.HighestMove = higherMove(stats.HighestMove, &move)
+				if IsBingo(move) {
+					stats.Bingos++
+				}
+			} else {
+				stats.OpponentHighestMove = higherMove(stats.OpponentHighestMove, &move)
+				if IsBingo(move) {
+					stats.OpponentBingos++
+				}
+			}
+		}
+	}
+
+	var score, opponentScore, spread, duration int64
+	durations, results := 0, 0
+	for _, game := range ended {
+		seconds, ok := gameDuration(game)
+		if ok {
+			if durations == 0 || seconds < stats.ShortestDuration {
+				stats.ShortestDuration = seconds
+			}
+			if seconds > stats.LongestDuration {
+				stats.LongestDuration = seconds
+			}
+			duration += seconds
+			durations++
+		}
+
+		result, known := GameResult(game)
+		if !known {
+			stats.UnknownResults++
+			continue
+		}
+		results++
+		switch result.Outcome {
+		case model.OutcomeWon:
+			stats.Wins++
+		case model.OutcomeLost:
+			stats.Losses++
+		default:
+			stats.Draws++
+		}
+		score += int64(result.MyScore)
+		opponentScore += int64(result.OpponentScore)
+		spread += int64(result.Spread)
+	}
+
+	if results > 0 {
+		stats.AverageScore = float64(score) / float64(results)
+		stats.AverageOpponentScore = float64(opponentScore) / float64(results)
+		stats.AverageSpread = float64(spread) / float64(results)
+	}
+	if durations > 0 {
+		stats.AverageDuration = duration / int64(durations)
+	}
+	return stats
+}
+
+// higherMove returns the move with more points, the earlier one on a tie.
+func higherMove(highest, move *model.PlayedMove) *model.PlayedMove {
+	if highest == nil || move.Points > highest.Points {
+		return move
+	}
+	return highest
+}
+
+// gameDuration returns the seconds from the start to the end of the game.
+func gameDuration(game model.UserGame) (int64, bool) {
+	start, err := time.Parse("2006-01-02 15:04:05", game.GameStartTimestamp)
+	if err != nil {
+		return 0, false
+	}
+	end, err := time.Parse("2006-01-02 15:04:05", game.GameEndTimestamp)
+	if err != nil || end.Before(start) {
+		return 0, false
+	}
+	return int64(end.Sub(start).Seconds()), true
+}
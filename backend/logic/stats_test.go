@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBingo(t *testing.T) {
+	assert.True(t, IsBingo(model.PlayedMove{Letters: "spieler"}))
+	assert.True(t, IsBingo(model.PlayedMove{Letters: "spieler", Placement: &model.Placement{Blanks: []int{0}}}))
+	assert.False(t, IsBingo(model.PlayedMove{Letters: "spiel"}))
+}
+
+func TestOpponentStatistics(t *testing.T) {
+	won := model.UserGame{
+		GameStartTimestamp: "2024-01-01 10:00:00",
+		GameEndTimestamp:   "2024-01-01 11:00:00",
+		PlayedMoves: []model.PlayedMove{
+			{Letters: "spieler", Points: 80, PlayedByMyself: true},
+			{Letters: "ab", Points: 10},
+		},
+		Result: &model.GameResult{MyScore: 84, OpponentScore: 6, Spread: 78, Outcome: model.OutcomeWon},
+	}
+	// ended before results were kept
+	lost := model.UserGame{
+		GameStartTimestamp: "2024-02-01 10:00:00",
+		GameEndTimestamp:   "2024-02-01 13:00:00",
+		PlayedMoves: []model.PlayedMove{
+			{Letters: "hut", Points: 8, PlayedByMyself: true},
+			{Letters: "quarken", Points: 90},
+		},
+	}
+	drawn := model.UserGame{GameStartTimestamp: "unknown", PlayedMoves: []model.PlayedMove{
+		{Letters: "ob", Points: 5, PlayedByMyself: true},
+		{Letters: "bo", Points: 5},
+	}}
+	// only the opponent's moves were recorded
+	unknown := model.UserGame{
+		GameStartTimestamp: "2024-03-01 10:00:00",
+		GameEndTimestamp:   "2024-03-01 12:00:00",
+		PlayedMoves:        []model.PlayedMove{{Letters: "ja", Points: 12}},
+	}
+	active := model.UserGame{PlayedMoves: []model.PlayedMove{{Letters: "xylofon", Points: 95, PlayedByMyself: true}}}
+
+	stats := OpponentStatistics("testuser", []model.UserGame{active}, []model.UserGame{won, lost, drawn, unknown})
+	assert.Equal(t, "testuser", stats.User)
+	assert.Equal(t, 4, stats.Games)
+	assert.Equal(t, 1, stats.ActiveGames)
+	assert.Equal(t, 1, stats.Wins)
+	assert.Equal(t, 1, stats.Losses)
+	assert.Equal(t, 1, stats.Draws)
+	assert.Equal(t, 1, stats.UnknownResults, "Expected the game without my moves to have no outcome")
+	assert.InDelta(t, 97.0/3, stats.AverageScore, 0.001)
+	assert.InDelta(t, 101.0/3, stats.AverageOpponentScore, 0.001)
+	assert.InDelta(t, -4.0/3, stats.AverageSpread, 0.001)
+	assert.Equal(t, "xylofon", stats.HighestMove.Letters, "Expected active games to count for the moves")
+	assert.Equal(t, "quarken", stats.OpponentHighestMove.Letters)
+	assert.Equal(t, 2, stats.Bingos)
+	assert.Equal(t, 1, stats.OpponentBingos)
+	assert.Equal(t, int64(3600), stats.ShortestDuration)
+	assert.Equal(t, int64(10800), stats.LongestDuration)
+	assert.Equal(t, int64(7200), stats.AverageDuration, "Expected games without timestamps to be left out")
+
+	_, known := GameResult(unknown)
+	assert.False(t, known)
+
+	stats = OpponentStatistics("testuser", []model.UserGame{active}, nil)
+	assert.Zero(t, stats.AverageScore)
+	assert.Nil(t, stats.OpponentHighestMove)
+}
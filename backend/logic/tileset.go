@@ -0,0 +1,87 @@
+package logic
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"buchstaben.go/model"
+)
+
+// DefaultLanguage is used for games created without a language.
+const DefaultLanguage = "de"
+
+// Spanish has no tile set: Wordfeud's Spanish set has CH, LL and RR tiles,
+// and a letter is always a single character in the letter sets and racks.
+//
+//go:embed tilesets/*.json
+var tileSetFiles embed.FS
+
+type tileSpec struct {
+	Letter string `json:"letter"`
+	Count  uint   `json:"count"`
+	Value  uint   `json:"value"`
+}
+
+// tileSets holds the tile distribution of every language, keyed by the
+// language code of the file name.
+var tileSets = loadTileSets()
+
+func loadTileSets() map[string][]tileSpec {
+	entries, err := tileSetFiles.ReadDir("tilesets")
+	if err != nil {
+		panic(err)
+	}
+	sets := make(map[string][]tileSpec, len(entries))
+	for _, entry := range entries {
+		file, err := tileSetFiles.ReadFile(path.Join("tilesets", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var specs []tileSpec
+		if err := json.Unmarshal(file, &specs); err != nil {
+			panic(fmt.Sprintf("tile set %s: %v", entry.Name(), err))
+		}
+		sets[strings.TrimSuffix(entry.Name(), ".json")] = specs
+	}
+	return sets
+}
+
+// Languages returns the codes of all available tile sets.
+func Languages() []string {
+	languages := make([]string, 0, len(tileSets))
+	for language := range tileSets {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// LanguageOrDefault returns the default language for games stored without one.
+func LanguageOrDefault(language string) string {
+	if language == "" {
+		return DefaultLanguage
+	}
+	return language
+}
+
+// LoadLettersPlaySetFor returns a full set of tiles of the language.
+func LoadLettersPlaySetFor(language string) (model.LettersPlaySet, error) {
+	specs, exists := tileSets[LanguageOrDefault(language)]
+	if !exists {
+		return nil, fmt.Errorf("language %q is not supported, use one of %s", language, strings.Join(Languages(), ", "))
+	}
+	lettersPlaySet := make(model.LettersPlaySet, 0, len(specs))
+	for _, spec := range specs {
+		lettersPlaySet = append(lettersPlaySet, model.LetterPlaySet{
+			Letter:        spec.Letter,
+			OriginalCount: spec.Count,
+			CurrentCount:  spec.Count,
+			Value:         spec.Value,
+		})
+	}
+	return lettersPlaySet, nil
+}
@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, []string{"da", "de", "en", "fr", "nl", "no", "sv"}, Languages())
+}
+
+func TestLoadLettersPlaySetFor(t *testing.T) {
+	// the number of tiles of the Wordfeud sets
+	totals := map[string]uint{"da": 104, "de": 102, "en": 104, "fr": 102, "nl": 103, "no": 104, "sv": 103}
+	for _, language := range Languages() {
+		t.Run(language, func(t *testing.T) {
+			lettersPlaySet, err := LoadLettersPlaySetFor(language)
+			assert.NoError(t, err)
+
+			seen := make(map[string]bool)
+			for _, l := range lettersPlaySet {
+				assert.False(t, seen[l.Letter], "Letter %q should only be listed once", l.Letter)
+				seen[l.Letter] = true
+				assert.Equal(t, l.OriginalCount, l.CurrentCount, "Letter %q should be complete", l.Letter)
+				assert.Len(t, []rune(l.Letter), 1, "Letter %q should be a single character", l.Letter)
+			}
+			assert.True(t, seen["*"], "Every language should have blanks")
+			assert.Equal(t, totals[language], GetRemindingsLetterCount(lettersPlaySet), "Set should have all tiles of the Wordfeud set")
+		})
+	}
+
+	english, err := LoadLettersPlaySetFor("en")
+	assert.NoError(t, err)
+	values := LetterValues(english)
+	assert.Equal(t, uint(10), values["z"], "English 'z' should have value 10")
+	assert.NotContains(t, values, "ä", "English set should not have umlauts")
+
+	german, err := LoadLettersPlaySetFor("")
+	assert.NoError(t, err)
+	assert.Equal(t, LoadLettersPlaySet(), german, "Empty language should load the default set")
+
+	_, err = LoadLettersPlaySetFor("xx")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+}
@@ -0,0 +1,169 @@
+package logic
+
+type trieNode struct {
+	children map[rune]*trieNode
+	// word is set on nodes that end a word
+	word string
+}
+
+// WordIndex is a trie of a word list. Racks are matched by walking only the
+// branches the letters allow, instead of testing every word.
+type WordIndex struct {
+	root  *trieNode
+	count int
+}
+
+// WordMatch is a word which can be built out of a rack.
+type WordMatch struct {
+	Word string
+	// BlankLetters are the letters played with a blank, in word order
+	BlankLetters []string
+}
+
+// NewWordIndex indexes all playable words of the alphabet.
+func NewWordIndex(words []string, alphabet []rune) *WordIndex {
+	wi := &WordIndex{root: &trieNode{}}
+	allowed := AlphabetSet(alphabet)
+	for _, word := range words {
+		word = NormalizeWord(word)
+		if !IsPlayableWord(word, allowed) {
+			continue
+		}
+		node := wi.root
+		for _, letter := range word {
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			next, exists := node.children[letter]
+			if !exists {
+				next = &trieNode{}
+				node.children[letter] = next
+			}
+			node = next
+		}
+		if node.word == "" {
+			node.word = word
+			wi.count++
+		}
+	}
+	return wi
+}
+
+// Len returns the number of words indexed.
+func (wi *WordIndex) Len() int {
+	return wi.count
+}
+
+func (wi *WordIndex) Contains(word string) bool {
+	node := wi.root
+	for _, letter := range NormalizeWord(word) {
+		node = node.children[letter]
+		if node == nil {
+			return false
+		}
+	}
+	return node.word != ""
+}
+
+// Match returns every word which can be built out of the letters, "*" is a
+// blank. Letters of the rack are used before blanks.
+func (wi *WordIndex) Match(letters string) []WordMatch {
+	rack := make(map[rune]int)
+	for _, letter := range NormalizeWord(letters) {
+		rack[letter]++
+	}
+	matches := []WordMatch{}
+	var blanks []string
+	var walk func(node *trieNode)
+	walk = func(node *trieNode) {
+		if node.word != "" {
+			matches = append(matches, WordMatch{Word: node.word, BlankLetters: append([]string(nil), blanks...)})
+		}
+		for letter, child := range node.children {
+			switch {
+			case rack[letter] > 0:
+				rack[letter]--
+				walk(child)
+				rack[letter]++
+			case rack['*'] > 0:
+				rack['*']--
+				blanks = append(blanks, string(letter))
+				walk(child)
+				blanks = blanks[:len(blanks)-1]
+				rack['*']++
+			}
+		}
+	}
+	walk(wi.root)
+	return matches
+}
+
+// OpenSquare marks a square of a pattern which has to be filled from the rack.
+const OpenSquare = '_'
+
+// MatchPattern returns every word which contains the pattern and whose
+// remaining letters can be built out of the letters, "*" is a blank. In the
+// pattern OpenSquare is an empty square, every other letter is already on
+// the board. Without anchorStart the word may begin before the pattern,
+// without anchorEnd it may continue after it. At least one letter has to be
+// played from the rack.
+func (wi *WordIndex) MatchPattern(pattern, letters string, anchorStart, anchorEnd bool, minLength, maxLength int) []WordMatch {
+	slots := []rune(NormalizeWord(pattern))
+	if maxLength <= 0 || maxLength > BoardSize {
+		maxLength = BoardSize
+	}
+	rack := make(map[rune]int)
+	for _, letter := range NormalizeWord(letters) {
+		rack[letter]++
+	}
+
+	seen := make(map[string]bool)
+	matches := []WordMatch{}
+	var blanks []string
+	var offset, used int
+	var walk func(node *trieNode, depth int)
+	walk = func(node *trieNode, depth int) {
+		end := offset + len(slots)
+		if node.word != "" && depth >= end && depth >= minLength && used > 0 && !seen[node.word] {
+			seen[node.word] = true
+			matches = append(matches, WordMatch{Word: node.word, BlankLetters: append([]string(nil), blanks...)})
+		}
+		if depth >= maxLength || (anchorEnd && depth >= end) {
+			return
+		}
+		pos := depth - offset
+		if pos >= 0 && pos < len(slots) && slots[pos] != OpenSquare {
+			if child := node.children[slots[pos]]; child != nil {
+				walk(child, depth+1)
+			}
+			return
+		}
+		for letter, child := range node.children {
+			switch {
+			case rack[letter] > 0:
+				rack[letter]--
+				used++
+				walk(child, depth+1)
+				used--
+				rack[letter]++
+			case rack['*'] > 0:
+				rack['*']--
+				used++
+				blanks = append(blanks, string(letter))
+				walk(child, depth+1)
+				blanks = blanks[:len(blanks)-1]
+				used--
+				rack['*']++
+			}
+		}
+	}
+
+	maxOffset := maxLength - len(slots)
+	if anchorStart {
+		maxOffset = 0
+	}
+	for offset = 0; offset <= maxOffset; offset++ {
+		walk(wi.root, 0)
+	}
+	return matches
+}
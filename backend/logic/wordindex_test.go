@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordIndexMatch(t *testing.T) {
+	index := NewWordIndex([]string{"hallo", "Hallo", "hall", "all", "öl", "Straße", "web-seite", "a"}, Alphabet(LoadLettersPlaySet()))
+
+	assert.Equal(t, 5, index.Len(), "Only playable words should be indexed once")
+	assert.True(t, index.Contains("strasse"), "'ß' should be indexed as 'ss'")
+	assert.True(t, index.Contains("Straße"), "Lookups should be normalized")
+	assert.False(t, index.Contains("hal"), "Prefixes should not be found")
+
+	testCases := []struct {
+		name     string
+		letters  string
+		expected []WordMatch
+	}{
+		{
+			name:    "Exact letters",
+			letters: "hallo",
+			expected: []WordMatch{
+				{Word: "all"},
+				{Word: "hall"},
+				{Word: "hallo"},
+			},
+		},
+		{
+			name:    "Blank fills missing letter",
+			letters: "halo*",
+			expected: []WordMatch{
+				{Word: "all", BlankLetters: []string{"l"}},
+				{Word: "hall", BlankLetters: []string{"l"}},
+				{Word: "hallo", BlankLetters: []string{"l"}},
+				{Word: "öl", BlankLetters: []string{"ö"}},
+			},
+		},
+		{
+			name:    "Umlauts and upper case",
+			letters: "ÖL",
+			expected: []WordMatch{
+				{Word: "öl"},
+			},
+		},
+		{
+			name:     "No match",
+			letters:  "xyz",
+			expected: []WordMatch{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := index.Match(tc.letters)
+			sort.Slice(result, func(i, j int) bool { return result[i].Word < result[j].Word })
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestWordIndexMatchPattern(t *testing.T) {
+	index := NewWordIndex([]string{"haus", "maus", "laus", "raus", "aus", "hausen", "zuhause"}, Alphabet(LoadLettersPlaySet()))
+
+	testCases := []struct {
+		name        string
+		pattern     string
+		letters     string
+		anchorStart bool
+		anchorEnd   bool
+		minLength   int
+		maxLength   int
+		expected    []string
+	}{
+		{name: "Open square", pattern: "_aus", letters: "hm", anchorStart: true, anchorEnd: true, expected: []string{"haus", "maus"}},
+		{name: "Open square with blank", pattern: "_aus", letters: "*", anchorStart: true, anchorEnd: true, expected: []string{"haus", "laus", "maus", "raus"}},
+		{name: "Prefix", pattern: "haus", letters: "ne", anchorStart: true, expected: []string{"hausen"}},
+		{name: "Suffix", pattern: "aus", letters: "hr", anchorEnd: true, expected: []string{"haus", "raus"}},
+		{name: "Anywhere", pattern: "aus", letters: "hzuen", expected: []string{"haus", "hausen", "zuhause"}},
+		{name: "Max length", pattern: "aus", letters: "hzuen", maxLength: 5, expected: []string{"haus"}},
+		{name: "Min length", pattern: "aus", letters: "hzuen", minLength: 6, expected: []string{"hausen", "zuhause"}},
+		{name: "No rack letter used", pattern: "aus", letters: "", anchorStart: true, anchorEnd: true, expected: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := index.MatchPattern(tc.pattern, tc.letters, tc.anchorStart, tc.anchorEnd, tc.minLength, tc.maxLength)
+			words := []string{}
+			for _, match := range result {
+				words = append(words, match.Word)
+			}
+			sort.Strings(words)
+			assert.Equal(t, tc.expected, words)
+		})
+	}
+
+	result := index.MatchPattern("_au_", "h*", true, true, 0, 0)
+	assert.Len(t, result, 1)
+	assert.Equal(t, WordMatch{Word: "haus", BlankLetters: []string{"s"}}, result[0], "Blank should be reported")
+}
@@ -12,61 +12,240 @@ type LetterPlaySet struct {
 type LettersPlaySet []LetterPlaySet
 
 type ListGame struct {
+	ID                 string `json:"id"`
 	User               string `json:"user"`
 	LastMoveTimestamp  string `json:"last_move_timestamp"`
 	GameStartTimestamp string `json:"game_start_timestamp"`
 	RemindingLetters   uint   `json:"reminding_letters"`
+	MyScore            int    `json:"my_score"`
+	OpponentScore      int    `json:"opponent_score"`
 }
 
 type ListEndedGame struct {
-	User               string `json:"user"`
-	LastMoveTimestamp  string `json:"last_move_timestamp"`
-	GameStartTimestamp string `json:"game_start_timestamp"`
+	ID                 string      `json:"id"`
+	User               string      `json:"user"`
+	LastMoveTimestamp  string      `json:"last_move_timestamp"`
+	GameStartTimestamp string      `json:"game_start_timestamp"`
+	GameEndTimestamp   string      `json:"game_end_timestamp"`
+	Result             *GameResult `json:"result,omitempty"`
+}
+
+// Outcomes of a game, seen from my side
+const (
+	OutcomeWon  = "won"
+	OutcomeLost = "lost"
+	OutcomeDraw = "draw"
+)
+
+// GameResult is the final score of an ended game. The rack adjustments are
+// the points won or lost for the tiles left on the racks, they are included
+// in the scores.
+type GameResult struct {
+	MyScore                int    `json:"my_score"`
+	OpponentScore          int    `json:"opponent_score"`
+	Spread                 int    `json:"spread"`
+	Outcome                string `json:"outcome"`
+	MyRackAdjustment       int    `json:"my_rack_adjustment"`
+	OpponentRackAdjustment int    `json:"opponent_rack_adjustment"`
+}
+
+// Direction of a placement on the board.
+const (
+	Horizontal = "horizontal"
+	Vertical   = "vertical"
+)
+
+// Placement describes where the letters of a move were put on the board.
+// Row and Col address the first newly placed tile, occupied squares along
+// the direction are skipped. Blanks holds the indexes into Letters that were
+// played with a blank tile.
+type Placement struct {
+	Row       int    `json:"row"`
+	Col       int    `json:"col"`
+	Direction string `json:"direction"`
+	Blanks    []int  `json:"blanks,omitempty"`
 }
 
 type PlayedMove struct {
-	Letters        string   `json:"letters"`
-	Words          []string `json:"words"`
-	PlayedByMyself bool     `json:"played_by_myself"`
-	Timestamp      string   `json:"timestamp"`
-	Points         uint     `json:"points"`
+	Letters        string     `json:"letters"`
+	Words          []string   `json:"words"`
+	PlayedByMyself bool       `json:"played_by_myself"`
+	Timestamp      string     `json:"timestamp"`
+	Points         uint       `json:"points"`
+	Placement      *Placement `json:"placement,omitempty"`
 }
 
+// PlacedTile is a single tile lying on the board.
+type PlacedTile struct {
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+	Letter string `json:"letter"`
+	Blank  bool   `json:"blank"`
+}
+
+type Board []PlacedTile
+
 type UserGame struct {
+	// ID identifies the game, there can be several games against a user
+	ID                 string          `json:"id"`
 	User               string          `json:"user"`
+	Language           string          `json:"language"`
 	LettersPlaySet     []LetterPlaySet `json:"letters_play_set"`
 	LastMoveTimestamp  string          `json:"last_move_timestamp"`
 	GameStartTimestamp string          `json:"game_start_timestamp"`
 	GameEndTimestamp   string          `json:"game_end_timestamp"`
 	LetterOverAllValue uint            `json:"letter_overall_value"`
 	PlayedMoves        []PlayedMove    `json:"played_moves"`
+	Board              Board           `json:"board"`
+	// Rack holds the tiles on my own rack, blanks as "*"
+	Rack string `json:"rack,omitempty"`
+	// MyScore and OpponentScore are the points of the played moves
+	MyScore       int `json:"my_score"`
+	OpponentScore int `json:"opponent_score"`
+	// Result is set once the game ended
+	Result *GameResult `json:"result,omitempty"`
+	// Events is the append-only log of everything that happened to the
+	// game. It is the source of truth, the state above is rebuilt out of it
+	// whenever the games are loaded. Games stored before the log was kept
+	// have none until their next event.
+	Events []GameEvent `json:"events,omitempty"`
+}
+
+// Types of game events
+const (
+	EventCreated     = "created"
+	EventMovePlayed  = "move_played"
+	EventMoveEdited  = "move_edited"
+	EventMoveDeleted = "move_deleted"
+	EventRackSet     = "rack_set"
+	EventEnded       = "ended"
+)
+
+// GameEvent is an entry of the append-only log of a game. Index is the
+// position of the move an edit or delete refers to. An ended event holds my
+// Rack, or PlayedOut if it was empty.
+type GameEvent struct {
+	Type      string      `json:"type"`
+	Timestamp string      `json:"timestamp"`
+	Language  string      `json:"language,omitempty"`
+	Index     int         `json:"index,omitempty"`
+	Move      *PlayedMove `json:"move,omitempty"`
+	Rack      string      `json:"rack,omitempty"`
+	PlayedOut bool        `json:"played_out,omitempty"`
+}
+
+// GameReplay is a game as it was after its first Move moves.
+type GameReplay struct {
+	ID                 string         `json:"id"`
+	User               string         `json:"user"`
+	Move               int            `json:"move"`
+	TotalMoves         int            `json:"total_moves"`
+	LettersPlaySet     LettersPlaySet `json:"letters_play_set"`
+	LetterOverAllValue uint           `json:"letter_overall_value"`
+	RemindingLetters   uint           `json:"reminding_letters"`
+	MyScore            int            `json:"my_score"`
+	OpponentScore      int            `json:"opponent_score"`
+	Board              Board          `json:"board"`
+	// LastMove is the move the replay stopped after, nil at the start
+	LastMove *PlayedMove `json:"last_move,omitempty"`
+}
+
+// OpponentStats sums up all games against one opponent. Results, averages
+// and durations count the ended games, moves and bingos every game. Ended
+// games without a known result count as UnknownResults only.
+type OpponentStats struct {
+	User                 string      `json:"user"`
+	Games                int         `json:"games"`
+	ActiveGames          int         `json:"active_games"`
+	Wins                 int         `json:"wins"`
+	Losses               int         `json:"losses"`
+	Draws                int         `json:"draws"`
+	UnknownResults       int         `json:"unknown_results"`
+	AverageScore         float64     `json:"average_score"`
+	AverageOpponentScore float64     `json:"average_opponent_score"`
+	AverageSpread        float64     `json:"average_spread"`
+	HighestMove          *PlayedMove `json:"highest_move,omitempty"`
+	OpponentHighestMove  *PlayedMove `json:"opponent_highest_move,omitempty"`
+	Bingos               int         `json:"bingos"`
+	OpponentBingos       int         `json:"opponent_bingos"`
+	// Durations are in seconds from the start to the end of a game
+	AverageDuration  int64 `json:"average_duration_seconds"`
+	ShortestDuration int64 `json:"shortest_duration_seconds"`
+	LongestDuration  int64 `json:"longest_duration_seconds"`
 }
 
+// Sources of the words found by a search
+const (
+	SourceDWDS   = "dwds"
+	SourceCustom = "custom"
+	SourcePlayed = "played"
+)
+
 type WordCount struct {
-	Word         string `json:"word"`
-	CurrentCount int    `json:"current_count"`
+	Word         string   `json:"word"`
+	CurrentCount int      `json:"current_count"`
+	Points       uint     `json:"points"`
+	BlankLetters []string `json:"blank_letters,omitempty"`
+	Source       string   `json:"source"`
+}
+
+// PatternQuery searches words for a row or column of the board. In the
+// pattern "_" is an empty square, letters are already on the board.
+type PatternQuery struct {
+	Pattern   string
+	Letters   string
+	Language  string
+	Category  string
+	Anchor    string
+	MinLength int
+	MaxLength int
 }
 
+// Categories of custom words with a meaning for the word searches. Words of
+// CategoryInvalid are removed from the results even if the word list knows
+// them, words of every other category are added.
+const (
+	CategoryAllowed = "allowed"
+	CategoryInvalid = "invalid"
+)
+
 type CustomWord struct {
 	Word      string `json:"word"`
 	Category  string `json:"category"`
+	Language  string `json:"language,omitempty"`
 	Timestamp string `json:"timestamp"`
 }
 type CustomWords struct {
 	Words    []string `json:"words"`
 	Category string   `json:"category"`
+	Language string   `json:"language,omitempty"`
+}
+
+// ImportResult counts the words of a bulk import.
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Updated  int `json:"updated"`
+	Skipped  int `json:"skipped"`
 }
 
 type WordMap map[string]string
 
 type GlobalPersistenceStruct struct {
-	Games       map[string]UserGame `json:"games"`
-	EndedGames  []UserGame          `json:"ended_games"`
-	CustomWords []CustomWord        `json:"custom_words"`
+	// SchemaVersion is the version of the file format, older files are
+	// migrated when they are loaded
+	SchemaVersion int                 `json:"schema_version"`
+	Games         map[string]UserGame `json:"games"`
+	EndedGames    []UserGame          `json:"ended_games"`
+	CustomWords   []CustomWord        `json:"custom_words"`
 }
 
 var (
 	GlobalPersistence GlobalPersistenceStruct
 	GamesLock         sync.Mutex
-	GlobalWordMap     WordMap
+	// GlobalDictionaries holds the word list of every language, keyed by
+	// language code.
+	GlobalDictionaries map[string]WordMap
+	// GlobalMissingDictionaries holds the path of the word list of every
+	// language none of whose word lists could be loaded.
+	GlobalMissingDictionaries map[string]string
 )
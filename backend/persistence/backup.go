@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"buchstaben.go/model"
+)
+
+// BackupRestorer is implemented by savers which keep backups.
+type BackupRestorer interface {
+	ListBackups() ([]string, error)
+	RestoreBackup(name string) error
+}
+
+// backupTimeFormat sorts the backups by age when sorted by name.
+const backupTimeFormat = "20060102-150405.000000"
+
+// writeFileAtomic writes the data to a temporary file next to the path and
+// renames it, so the path holds either the old or the new data after a
+// crash.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	// the rename is only durable once the directory is synced
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// backupPrefix and backupExt frame the timestamp in the backup file names.
+func (fds *FileDataSaver) backupPrefix() (string, string) {
+	base := filepath.Base(fds.GameFilePath)
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// backup copies the games file into the backup directory and removes the
+// oldest backups. Nothing is done without a backup directory or games file.
+func (fds *FileDataSaver) backup() error {
+	if fds.BackupDir == "" || fds.Backups <= 0 {
+		return nil
+	}
+	current, err := os.ReadFile(fds.GameFilePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(fds.BackupDir, 0755); err != nil {
+		return err
+	}
+	prefix, ext := fds.backupPrefix()
+	name := prefix + time.Now().Format(backupTimeFormat) + ext
+	if err := writeFileAtomic(filepath.Join(fds.BackupDir, name), current); err != nil {
+		return err
+	}
+
+	backups, err := fds.ListBackups()
+	if err != nil {
+		return err
+	}
+	for i := fds.Backups; i < len(backups); i++ {
+		if err := os.Remove(filepath.Join(fds.BackupDir, backups[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBackups returns the names of the backups, newest first.
+func (fds *FileDataSaver) ListBackups() ([]string, error) {
+	backups := []string{}
+	if fds.BackupDir == "" {
+		return backups, nil
+	}
+	entries, err := os.ReadDir(fds.BackupDir)
+	if os.IsNotExist(err) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix, ext := fds.backupPrefix()
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, ext) {
+			backups = append(backups, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// RestoreBackup replaces the games file and the GlobalPersistence with the
+// backup. The games file is backed up before, so a restore can be undone.
+func (fds *FileDataSaver) RestoreBackup(name string) error {
+	backups, err := fds.ListBackups()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, backup := range backups {
+		found = found || backup == name
+	}
+	if !found {
+		return fmt.Errorf("backup %q not found", name)
+	}
+
+	file, err := os.ReadFile(filepath.Join(fds.BackupDir, name))
+	if err != nil {
+		return err
+	}
+	restored, err := DecodeGames(file)
+	if err != nil {
+		return fmt.Errorf("backup %q is not valid: %w", name, err)
+	}
+	if err := fds.backup(); err != nil {
+		return fmt.Errorf("failed to back up games: %w", err)
+	}
+	if err := writeFileAtomic(fds.GameFilePath, file); err != nil {
+		return err
+	}
+	model.GlobalPersistence = restored
+	fds.events = eventLogs(restored)
+	return nil
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "games.json")
+
+	assert.NoError(t, writeFileAtomic(path, []byte("first")))
+	assert.NoError(t, writeFileAtomic(path, []byte("second")))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(content))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "Expected no temporary file to be left")
+
+	assert.Error(t, writeFileAtomic("/nonexistent/directory/games.json", []byte("x")))
+}
+
+func TestSaveGamesToFile_Backups(t *testing.T) {
+	dir := t.TempDir()
+	saver := &FileDataSaver{
+		GameFilePath: filepath.Join(dir, "games.json"),
+		BackupDir:    filepath.Join(dir, "backups"),
+		Backups:      2,
+	}
+
+	for _, user := range []string{"first", "second", "third", "fourth"} {
+		model.GlobalPersistence = model.GlobalPersistenceStruct{
+			Games: map[string]model.UserGame{user: {User: user}},
+		}
+		assert.NoError(t, saver.SaveGamesToFile())
+		// backups are named by the time they are taken
+		time.Sleep(time.Millisecond)
+	}
+
+	backups, err := saver.ListBackups()
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2, "Expected only the newest backups to be kept")
+
+	// The newest backup holds the games before the last save
+	assert.NoError(t, saver.RestoreBackup(backups[0]))
+	assert.Contains(t, model.GlobalPersistence.Games, "third")
+	persistence, err := ReadGamesFile(saver.GameFilePath)
+	assert.NoError(t, err)
+	assert.Contains(t, persistence.Games, "third", "Expected the games file to be restored")
+
+	// The restore itself can be rolled back
+	backups, err = saver.ListBackups()
+	assert.NoError(t, err)
+	assert.NoError(t, saver.RestoreBackup(backups[0]))
+	assert.Contains(t, model.GlobalPersistence.Games, "fourth")
+
+	assert.Error(t, saver.RestoreBackup("../games.json"), "Expected error for a file which is no backup")
+	assert.Error(t, saver.RestoreBackup("missing.json"))
+}
+
+func TestSaveGamesToFile_NoBackups(t *testing.T) {
+	saver := &FileDataSaver{GameFilePath: filepath.Join(t.TempDir(), "games.json")}
+	model.GlobalPersistence = model.GlobalPersistenceStruct{Games: map[string]model.UserGame{}}
+
+	assert.NoError(t, saver.SaveGamesToFile())
+	assert.NoError(t, saver.SaveGamesToFile())
+	backups, err := saver.ListBackups()
+	assert.NoError(t, err)
+	assert.Empty(t, backups)
+}
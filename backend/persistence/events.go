@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"fmt"
+	"reflect"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// replayGames rebuilds every game with an event log out of it, the log is
+// the source of truth and the stored state only a cache of it. Games stored
+// before the log was kept have none and keep their stored state until their
+// next event, as do games whose log cannot be replayed anymore.
+func replayGames(persistence *model.GlobalPersistenceStruct) {
+	for gameID, game := range persistence.Games {
+		persistence.Games[gameID] = replayGame(game)
+	}
+	for i, game := range persistence.EndedGames {
+		persistence.EndedGames[i] = replayGame(game)
+	}
+}
+
+func replayGame(game model.UserGame) model.UserGame {
+	if len(game.Events) == 0 {
+		return game
+	}
+	replayed, err := logic.ReplayEvents(game, game.Events, -1)
+	if err != nil {
+		fmt.Printf("Keeping the stored state of the game of %s, its event log cannot be replayed: %v\n", game.User, err)
+		return game
+	}
+	return replayed
+}
+
+// eventLogs returns the event logs of the active and ended games by id.
+func eventLogs(persistence model.GlobalPersistenceStruct) map[string][]model.GameEvent {
+	logs := make(map[string][]model.GameEvent, len(persistence.Games)+len(persistence.EndedGames))
+	for gameID, game := range persistence.Games {
+		logs[gameID] = game.Events
+	}
+	for _, game := range persistence.EndedGames {
+		logs[game.ID] = game.Events
+	}
+	return logs
+}
+
+// checkEventLogs returns an error if the log of a stored game lost or
+// changed events, the logs can only be appended to. Deleted games are not
+// checked.
+func checkEventLogs(stored map[string][]model.GameEvent, persistence model.GlobalPersistenceStruct) error {
+	for gameID, events := range eventLogs(persistence) {
+		previous := stored[gameID]
+		if len(previous) == 0 {
+			continue
+		}
+		if len(events) < len(previous) || !reflect.DeepEqual(events[:len(previous)], previous) {
+			return fmt.Errorf("the event log of game %s was changed, events can only be appended", gameID)
+		}
+	}
+	return nil
+}
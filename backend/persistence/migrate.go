@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+
+	"buchstaben.go/model"
+)
+
+// ReadGamesFile reads a games file of any schema version without touching
+// the GlobalPersistence.
+func ReadGamesFile(path string) (model.GlobalPersistenceStruct, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return model.GlobalPersistenceStruct{}, err
+	}
+	persistence, err := DecodeGames(file)
+	if err != nil {
+		return persistence, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return persistence, nil
+}
+
+// MergePersistence merges games files, later ones win. Ended games and
+// custom words are kept once, active games which ended in another file are
+// dropped.
+func MergePersistence(sources ...model.GlobalPersistenceStruct) model.GlobalPersistenceStruct {
+	merged := model.GlobalPersistenceStruct{
+		SchemaVersion: CurrentSchemaVersion,
+		Games:         make(map[string]model.UserGame),
+		EndedGames:    []model.UserGame{},
+		CustomWords:   []model.CustomWord{},
+	}
+	ended := make(map[string]bool)
+	customWords := make(map[string]bool)
+	for _, source := range sources {
+		for gameID, game := range source.Games {
+			merged.Games[gameID] = game
+		}
+		for _, game := range source.EndedGames {
+			key := game.User + "/" + game.GameStartTimestamp
+			if ended[key] {
+				continue
+			}
+			ended[key] = true
+			merged.EndedGames = append(merged.EndedGames, game)
+		}
+		for _, customWord := range source.CustomWords {
+			key := customWord.Language + "/" + customWord.Word
+			if customWords[key] {
+				continue
+			}
+			customWords[key] = true
+			merged.CustomWords = append(merged.CustomWords, customWord)
+		}
+	}
+	for gameID, game := range merged.Games {
+		if ended[game.User+"/"+game.GameStartTimestamp] {
+			delete(merged.Games, gameID)
+		}
+	}
+	return merged
+}
+
+// MigrateToSQLite copies the games files into an empty database.
+func MigrateToSQLite(saver *SQLiteDataSaver, gameFilePaths ...string) (model.GlobalPersistenceStruct, error) {
+	sources := make([]model.GlobalPersistenceStruct, 0, len(gameFilePaths))
+	for _, path := range gameFilePaths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			fmt.Println("Games file does not exist:", path)
+			continue
+		}
+		source, err := ReadGamesFile(path)
+		if err != nil {
+			return model.GlobalPersistenceStruct{}, err
+		}
+		sources = append(sources, source)
+	}
+	merged := MergePersistence(sources...)
+
+	db, err := saver.open()
+	if err != nil {
+		return merged, err
+	}
+	var games int
+	if err := db.QueryRow("SELECT COUNT(*) FROM games").Scan(&games); err != nil {
+		return merged, err
+	}
+	if games > 0 {
+		return merged, fmt.Errorf("database %s already holds %d games", saver.DatabasePath, games)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return merged, err
+	}
+	defer tx.Rollback()
+	if err := savePersistence(tx, merged, nil); err != nil {
+		return merged, err
+	}
+	return merged, tx.Commit()
+}
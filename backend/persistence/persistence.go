@@ -1,9 +1,13 @@
 package persistence
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"buchstaben.go/model"
 )
@@ -15,19 +19,68 @@ type DataSaver interface {
 	LoadWordListFromFile() error
 }
 type FileDataSaver struct {
-	GameFilePath     string
-	WordListFilePath string
+	GameFilePath string
+	WordLists    []WordListFile
+	// BackupDir keeps a copy of the games file before every save, the
+	// newest Backups copies are kept
+	BackupDir string
+	Backups   int
+	// events holds the event logs as last loaded or saved
+	events map[string][]model.GameEvent
+}
+
+// Word list formats
+const (
+	// JSONWordList is a JSON object with the words as keys, like the DWDS list.
+	JSONWordList = "json"
+	// TextWordList has one word per line, lines starting with "#" are skipped.
+	TextWordList = "text"
+)
+
+// WordListFile is the word list of one language on disk.
+type WordListFile struct {
+	Language string
+	Path     string
+	Format   string
+}
+
+// WordLists returns the word lists the server loads out of the data
+// directory. The full DWDS list is optional, the etymologic list ships with
+// the repository.
+func WordLists(dataDir string) []WordListFile {
+	return []WordListFile{
+		{Language: "de", Path: filepath.Join(dataDir, "dwds_word_list.json"), Format: JSONWordList},
+		{Language: "de", Path: filepath.Join(dataDir, "dwds_etymologic_word_list.json"), Format: JSONWordList},
+		{Language: "en", Path: filepath.Join(dataDir, "english_word_list.txt"), Format: TextWordList},
+		{Language: "nl", Path: filepath.Join(dataDir, "dutch_word_list.txt"), Format: TextWordList},
+	}
+}
+
+var wordListLoaders = map[string]func([]byte) (model.WordMap, error){
+	JSONWordList: loadJSONWordList,
+	TextWordList: loadTextWordList,
 }
 
 func (fds *FileDataSaver) SaveGamesToFile() error {
 	fmt.Println("Saving games to file...")
 
+	if err := checkEventLogs(fds.events, model.GlobalPersistence); err != nil {
+		return err
+	}
+	model.GlobalPersistence.SchemaVersion = CurrentSchemaVersion
 	file, err := json.MarshalIndent(model.GlobalPersistence, "", "  ")
 	if err != nil {
 		fmt.Println("Error marshalling games:", err)
 		return err
 	}
-	return os.WriteFile(fds.GameFilePath, file, 0644)
+	if err := fds.backup(); err != nil {
+		return fmt.Errorf("failed to back up games: %w", err)
+	}
+	if err := writeFileAtomic(fds.GameFilePath, file); err != nil {
+		return err
+	}
+	fds.events = eventLogs(model.GlobalPersistence)
+	return nil
 }
 
 func (fds *FileDataSaver) LoadGamesFromFile() error {
@@ -35,9 +88,10 @@ func (fds *FileDataSaver) LoadGamesFromFile() error {
 
 	if _, err := os.Stat(fds.GameFilePath); os.IsNotExist(err) {
 		model.GlobalPersistence = model.GlobalPersistenceStruct{
-			Games:       make(map[string]model.UserGame),
-			EndedGames:  []model.UserGame{},
-			CustomWords: []model.CustomWord{},
+			SchemaVersion: CurrentSchemaVersion,
+			Games:         make(map[string]model.UserGame),
+			EndedGames:    []model.UserGame{},
+			CustomWords:   []model.CustomWord{},
 		}
 		return nil
 	}
@@ -45,20 +99,74 @@ func (fds *FileDataSaver) LoadGamesFromFile() error {
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(file, &model.GlobalPersistence)
+	persistence, err := DecodeGames(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", fds.GameFilePath, err)
+	}
+	model.GlobalPersistence = persistence
+	fds.events = eventLogs(persistence)
+	return nil
 }
 
 func (fds *FileDataSaver) LoadWordListFromFile() error {
-	fmt.Println("Loading word list from file...")
+	return loadWordLists(fds.WordLists)
+}
 
-	if _, err := os.Stat(fds.WordListFilePath); os.IsNotExist(err) {
-		fmt.Println("Word list file does not exist")
-		model.GlobalWordMap = model.WordMap{}
-		return nil
+// loadWordLists loads the word lists into the GlobalDictionaries. Languages
+// without any word list file are kept in the GlobalMissingDictionaries.
+func loadWordLists(wordLists []WordListFile) error {
+	model.GlobalDictionaries = make(map[string]model.WordMap, len(wordLists))
+	model.GlobalMissingDictionaries = make(map[string]string)
+	for _, wordList := range wordLists {
+		fmt.Printf("Loading %s word list from file...\n", wordList.Language)
+
+		loader, exists := wordListLoaders[wordList.Format]
+		if !exists {
+			return fmt.Errorf("word list format %q of %s is not supported", wordList.Format, wordList.Path)
+		}
+		if _, err := os.Stat(wordList.Path); os.IsNotExist(err) {
+			fmt.Println("Word list file does not exist:", wordList.Path)
+			model.GlobalMissingDictionaries[wordList.Language] = wordList.Path
+			continue
+		}
+		file, err := os.ReadFile(wordList.Path)
+		if err != nil {
+			return err
+		}
+		wordMap, err := loader(file)
+		if err != nil {
+			return fmt.Errorf("failed to load word list %s: %w", wordList.Path, err)
+		}
+		// several files of the same language are merged
+		if existing, exists := model.GlobalDictionaries[wordList.Language]; exists {
+			for word, value := range wordMap {
+				existing[word] = value
+			}
+			continue
+		}
+		model.GlobalDictionaries[wordList.Language] = wordMap
 	}
-	file, err := os.ReadFile(fds.WordListFilePath)
-	if err != nil {
-		return err
+	for language := range model.GlobalDictionaries {
+		delete(model.GlobalMissingDictionaries, language)
+	}
+	return nil
+}
+
+func loadJSONWordList(file []byte) (model.WordMap, error) {
+	wordMap := model.WordMap{}
+	err := json.Unmarshal(file, &wordMap)
+	return wordMap, err
+}
+
+func loadTextWordList(file []byte) (model.WordMap, error) {
+	wordMap := model.WordMap{}
+	scanner := bufio.NewScanner(bytes.NewReader(file))
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		wordMap[word] = ""
 	}
-	return json.Unmarshal(file, &model.GlobalWordMap)
+	return wordMap, scanner.Err()
 }
@@ -124,12 +124,57 @@ func TestLoadGamesFromFile_ExistingFile(t *testing.T) {
 	// Check if data was loaded correctly
 	assert.Len(t, model.GlobalPersistence.Games, 1, "Should load 1 game")
 
-	game, exists := model.GlobalPersistence.Games["testuser"]
-	assert.True(t, exists, "Game for 'testuser' should exist")
+	// the games of files without a schema version are keyed by their id
+	var game model.UserGame
+	for gameID, loaded := range model.GlobalPersistence.Games {
+		assert.Equal(t, gameID, loaded.ID, "Game should be keyed by its id")
+		game = loaded
+	}
 	assert.Equal(t, "testuser", game.User, "User field should match")
 	assert.Equal(t, "2025-04-21 12:00:00", game.LastMoveTimestamp, "Last move timestamp should match")
 }
 
+func TestLoadGamesFromFile_ReplaysEvents(t *testing.T) {
+	// the stored state is stale, the event log holds a move more
+	testFilePath := createTempFile(t, `{
+		"schema_version": 4,
+		"games": {
+			"g1": {
+				"id": "g1",
+				"user": "testuser",
+				"my_score": 99,
+				"played_moves": [],
+				"events": [
+					{"type": "created", "timestamp": "2025-04-21 11:00:00", "language": "de"},
+					{"type": "move_played", "timestamp": "2025-04-21 12:00:00",
+						"move": {"letters": "hut", "points": 4, "played_by_myself": true, "placement": {"row": 7, "col": 6, "direction": "horizontal"}}}
+				]
+			}
+		},
+		"ended_games": []
+	}`)
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	saver := &FileDataSaver{GameFilePath: testFilePath}
+
+	err := saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	game := model.GlobalPersistence.Games["g1"]
+	assert.Len(t, game.PlayedMoves, 1, "Expected the game to be rebuilt out of its events")
+	assert.Len(t, game.Board, 3)
+	assert.Equal(t, 4, game.MyScore)
+	assert.Equal(t, "2025-04-21 11:00:00", game.GameStartTimestamp)
+
+	// the event log is only appended to
+	game.Events = game.Events[:1]
+	model.GlobalPersistence.Games["g1"] = game
+	assert.Error(t, saver.SaveGamesToFile(), "Expected error for a shortened event log")
+	game.Events = append(model.GlobalPersistence.Games["g1"].Events[:1:1], model.GameEvent{Type: model.EventRackSet, Rack: "xy"})
+	model.GlobalPersistence.Games["g1"] = game
+	assert.Error(t, saver.SaveGamesToFile(), "Expected error for a changed event log")
+	delete(model.GlobalPersistence.Games, "g1")
+	assert.NoError(t, saver.SaveGamesToFile(), "Expected a game to be deleted with its log")
+}
+
 func TestLoadGamesFromFile_InvalidJSON(t *testing.T) {
 	// Create invalid JSON content
 	invalidJSON := `{
@@ -172,3 +217,50 @@ func TestLoadGamesFromFile_ReadError(t *testing.T) {
 	// Verify
 	assert.Error(t, err, "Reading a directory as file should return error")
 }
+
+func TestLoadWordListFromFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonPath := filepath.Join(tmpDir, "de.json")
+	textPath := filepath.Join(tmpDir, "en.txt")
+	extraPath := filepath.Join(tmpDir, "en-extra.txt")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{"haus": "https://www.dwds.de/wb/haus", "übel": ""}`), 0644))
+	assert.NoError(t, os.WriteFile(textPath, []byte("# English words\nhouse\n\n  tree \r\n"), 0644))
+	assert.NoError(t, os.WriteFile(extraPath, []byte("zoo\n"), 0644))
+
+	saver := &FileDataSaver{
+		WordLists: []WordListFile{
+			{Language: "de", Path: jsonPath, Format: JSONWordList},
+			{Language: "en", Path: textPath, Format: TextWordList},
+			{Language: "en", Path: extraPath, Format: TextWordList},
+			{Language: "en", Path: filepath.Join(tmpDir, "nonexistent-en.txt"), Format: TextWordList},
+			{Language: "nl", Path: filepath.Join(tmpDir, "nonexistent.txt"), Format: TextWordList},
+		},
+	}
+
+	err := saver.LoadWordListFromFile()
+
+	assert.NoError(t, err, "Loading word lists should not return an error")
+	assert.Equal(t, model.WordMap{"haus": "https://www.dwds.de/wb/haus", "übel": ""}, model.GlobalDictionaries["de"], "JSON word list should be loaded")
+	assert.Equal(t, model.WordMap{"house": "", "tree": "", "zoo": ""}, model.GlobalDictionaries["en"], "Text word lists of the same language should be merged")
+	assert.Empty(t, model.GlobalDictionaries["nl"], "Missing word list should be empty")
+	assert.Equal(t, map[string]string{"nl": filepath.Join(tmpDir, "nonexistent.txt")}, model.GlobalMissingDictionaries,
+		"Only languages without any word list should be missing")
+}
+
+func TestLoadWordListFromFile_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+	invalidPath := filepath.Join(tmpDir, "invalid.json")
+	assert.NoError(t, os.WriteFile(invalidPath, []byte(`["not", "a", "map"]`), 0644))
+
+	saver := &FileDataSaver{
+		WordLists: []WordListFile{{Language: "de", Path: invalidPath, Format: JSONWordList}},
+	}
+	assert.Error(t, saver.LoadWordListFromFile(), "Invalid JSON word list should return error")
+
+	saver = &FileDataSaver{
+		WordLists: []WordListFile{{Language: "de", Path: invalidPath, Format: "xml"}},
+	}
+	err := saver.LoadWordListFromFile()
+	assert.Error(t, err, "Unknown format should return error")
+	assert.Contains(t, err.Error(), "not supported")
+}
@@ -0,0 +1,254 @@
+package persistence
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// CurrentSchemaVersion is the schema version of the games files written.
+// Files without a version are version 0.
+const CurrentSchemaVersion = 4
+
+// document is a games file decoded without a schema, so migrations can work
+// on fields the model does not know anymore.
+type document = map[string]any
+
+// schemaMigration upgrades a games file from the previous version to Version.
+type schemaMigration struct {
+	Version     int
+	Description string
+	Migrate     func(document) error
+}
+
+// schemaMigrations is ordered by version, every version has exactly one
+// migration.
+var schemaMigrations = []schemaMigration{
+	{Version: 1, Description: "moves list all words they formed", Migrate: migrateMoveWords},
+	{Version: 2, Description: "games name their language and board", Migrate: migrateGameDefaults},
+	{Version: 3, Description: "games total their scores and keep the result", Migrate: migrateScores},
+	{Version: 4, Description: "games are keyed by their id instead of the username", Migrate: migrateGameIDs},
+}
+
+// DecodeGames reads a games file of any schema version and upgrades it to
+// the CurrentSchemaVersion. Games are rebuilt out of their event logs.
+func DecodeGames(file []byte) (model.GlobalPersistenceStruct, error) {
+	persistence := model.GlobalPersistenceStruct{}
+	doc := document{}
+	if err := json.Unmarshal(file, &doc); err != nil {
+		return persistence, err
+	}
+	if err := upgradeDocument(doc, CurrentSchemaVersion); err != nil {
+		return persistence, err
+	}
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return persistence, err
+	}
+	if err := json.Unmarshal(upgraded, &persistence); err != nil {
+		return persistence, err
+	}
+	if persistence.Games == nil {
+		persistence.Games = make(map[string]model.UserGame)
+	}
+	if persistence.EndedGames == nil {
+		persistence.EndedGames = []model.UserGame{}
+	}
+	replayGames(&persistence)
+	return persistence, nil
+}
+
+// schemaVersion returns the version stored in the document.
+func schemaVersion(doc document) (int, error) {
+	raw, exists := doc["schema_version"]
+	if !exists || raw == nil {
+		return 0, nil
+	}
+	version, ok := raw.(float64)
+	if !ok || version != float64(int(version)) || version < 0 {
+		return 0, fmt.Errorf("schema version %v is not valid", raw)
+	}
+	return int(version), nil
+}
+
+// upgradeDocument applies the migrations after the version of the document
+// up to the target version, one step after the other.
+func upgradeDocument(doc document, target int) error {
+	version, err := schemaVersion(doc)
+	if err != nil {
+		return err
+	}
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("schema version %d is newer than the supported version %d", version, CurrentSchemaVersion)
+	}
+	for _, migration := range schemaMigrations {
+		if migration.Version <= version || migration.Version > target {
+			continue
+		}
+		fmt.Printf("Migrating games to schema version %d: %s\n", migration.Version, migration.Description)
+		if err := migration.Migrate(doc); err != nil {
+			return fmt.Errorf("migration to schema version %d failed: %w", migration.Version, err)
+		}
+		doc["schema_version"] = migration.Version
+	}
+	return nil
+}
+
+// gameDocuments returns the active and ended games of the document.
+func gameDocuments(doc document) ([]document, error) {
+	games := []document{}
+	if active, ok := doc["games"].(map[string]any); ok {
+		for user, raw := range active {
+			game, ok := raw.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("game of %s is not an object", user)
+			}
+			games = append(games, game)
+		}
+	}
+	if ended, ok := doc["ended_games"].([]any); ok {
+		for i, raw := range ended {
+			game, ok := raw.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("ended game %d is not an object", i)
+			}
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+// migrateMoveWords replaces the single "word" of the moves stored before a
+// move could form several words.
+func migrateMoveWords(doc document) error {
+	games, err := gameDocuments(doc)
+	if err != nil {
+		return err
+	}
+	for _, game := range games {
+		moves, _ := game["played_moves"].([]any)
+		for _, raw := range moves {
+			move, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			word, hasWord := move["word"].(string)
+			delete(move, "word")
+			if words, _ := move["words"].([]any); len(words) > 0 || !hasWord || word == "" {
+				continue
+			}
+			move["words"] = []any{word}
+		}
+	}
+	return nil
+}
+
+// migrateGameDefaults stores the language and the board of games written
+// before there was more than one language and a board.
+func migrateGameDefaults(doc document) error {
+	games, err := gameDocuments(doc)
+	if err != nil {
+		return err
+	}
+	for _, game := range games {
+		if language, _ := game["language"].(string); language == "" {
+			game["language"] = logic.DefaultLanguage
+		}
+		if game["board"] == nil {
+			game["board"] = []any{}
+		}
+		if game["played_moves"] == nil {
+			game["played_moves"] = []any{}
+		}
+	}
+	if doc["custom_words"] == nil {
+		doc["custom_words"] = []any{}
+	}
+	return nil
+}
+
+// migrateScores totals the points of the moves and stores the result of the
+// ended games. Ended games whose tiles do not add up get no result.
+func migrateScores(doc document) error {
+	games, err := gameDocuments(doc)
+	if err != nil {
+		return err
+	}
+	for _, raw := range games {
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		var game model.UserGame
+		if err := json.Unmarshal(encoded, &game); err != nil {
+			return fmt.Errorf("game of %s: %w", raw["user"], err)
+		}
+		raw["my_score"], raw["opponent_score"] = logic.Scores(game.PlayedMoves)
+		// without a move of mine the result would be made up
+		if game.GameEndTimestamp == "" || !logic.HasOwnMoves(game.PlayedMoves) {
+			continue
+		}
+		var rack *string
+		if game.Rack != "" {
+			rack = &game.Rack
+		}
+		if result, err := logic.FinalResult(game, rack); err == nil {
+			raw["result"] = result
+		}
+	}
+	return nil
+}
+
+// migrateGameIDs gives every game an id and keys the active games by it.
+func migrateGameIDs(doc document) error {
+	used := make(map[string]bool)
+	if active, ok := doc["games"].(map[string]any); ok {
+		games := make(map[string]any, len(active))
+		for user, raw := range active {
+			game, ok := raw.(map[string]any)
+			if !ok {
+				return fmt.Errorf("game of %s is not an object", user)
+			}
+			if _, ok := game["user"].(string); !ok {
+				game["user"] = user
+			}
+			gameID := migratedGameID(game, used)
+			games[gameID] = game
+		}
+		doc["games"] = games
+	}
+	if ended, ok := doc["ended_games"].([]any); ok {
+		for i, raw := range ended {
+			game, ok := raw.(map[string]any)
+			if !ok {
+				return fmt.Errorf("ended game %d is not an object", i)
+			}
+			migratedGameID(game, used)
+		}
+	}
+	return nil
+}
+
+// migratedGameID derives the id of a game out of its user and start, so a
+// file migrates to the same ids every time.
+func migratedGameID(game document, used map[string]bool) string {
+	if gameID, _ := game["id"].(string); gameID != "" && !used[gameID] {
+		used[gameID] = true
+		return gameID
+	}
+	user, _ := game["user"].(string)
+	start, _ := game["game_start_timestamp"].(string)
+	for i := 0; ; i++ {
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", user, start, i)))
+		gameID := hex.EncodeToString(hash[:8])
+		if !used[gameID] {
+			used[gameID] = true
+			game["id"] = gameID
+			return gameID
+		}
+	}
+}
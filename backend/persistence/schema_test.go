@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// readSchemaFixture reads testdata/schema_v<version>.json.
+func readSchemaFixture(t *testing.T, version int) []byte {
+	t.Helper()
+
+	file, err := os.ReadFile(filepath.Join("testdata", fmt.Sprintf("schema_v%d.json", version)))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return file
+}
+
+func TestSchemaMigrations(t *testing.T) {
+	for i, migration := range schemaMigrations {
+		assert.Equal(t, i+1, migration.Version, "Expected one migration per version in order")
+	}
+	assert.Equal(t, CurrentSchemaVersion, schemaMigrations[len(schemaMigrations)-1].Version)
+
+	for _, migration := range schemaMigrations {
+		t.Run(migration.Description, func(t *testing.T) {
+			doc := document{}
+			assert.NoError(t, json.Unmarshal(readSchemaFixture(t, migration.Version-1), &doc))
+
+			assert.NoError(t, upgradeDocument(doc, migration.Version))
+
+			upgraded, err := json.Marshal(doc)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(readSchemaFixture(t, migration.Version)), string(upgraded))
+		})
+	}
+}
+
+func TestDecodeGames(t *testing.T) {
+	persistence, err := DecodeGames(readSchemaFixture(t, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, CurrentSchemaVersion, persistence.SchemaVersion)
+	anna := persistence.Games["751a9d085fa80009"]
+	assert.Equal(t, "anna", anna.User, "Expected the games to be keyed by their id")
+	assert.Equal(t, "751a9d085fa80009", anna.ID)
+	assert.Equal(t, "fd00535a3700bea6", persistence.EndedGames[0].ID)
+	assert.Equal(t, []string{"ha"}, anna.PlayedMoves[0].Words)
+	assert.Equal(t, "de", anna.Language)
+	assert.Equal(t, []string{"ob", "ha"}, persistence.EndedGames[0].PlayedMoves[0].Words, "Expected existing words to be kept")
+	assert.NotNil(t, persistence.CustomWords)
+	assert.Equal(t, 6, anna.MyScore)
+	assert.Nil(t, persistence.EndedGames[0].Result, "Expected no result for a game without any move of mine")
+	assert.Equal(t, 9, persistence.EndedGames[0].OpponentScore)
+
+	current, err := DecodeGames(readSchemaFixture(t, CurrentSchemaVersion))
+	assert.NoError(t, err)
+	assert.Equal(t, persistence, current, "Expected every version to decode to the same games")
+
+	_, err = DecodeGames([]byte(`{"schema_version": 99, "games": {}}`))
+	assert.EqualError(t, err, "schema version 99 is newer than the supported version 4")
+
+	_, err = DecodeGames([]byte(`{"schema_version": "one"}`))
+	assert.Error(t, err)
+}
+
+func TestLoadGamesFromFile_Migrates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.json")
+	assert.NoError(t, os.WriteFile(path, readSchemaFixture(t, 0), 0644))
+	saver := &FileDataSaver{GameFilePath: path}
+
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	assert.NoError(t, saver.LoadGamesFromFile())
+	assert.Equal(t, []string{"ha"}, model.GlobalPersistence.Games["751a9d085fa80009"].PlayedMoves[0].Words)
+
+	assert.NoError(t, saver.SaveGamesToFile())
+	file, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	doc := document{}
+	assert.NoError(t, json.Unmarshal(file, &doc))
+	assert.EqualValues(t, CurrentSchemaVersion, doc["schema_version"], "Expected the saved file to have the current version")
+}
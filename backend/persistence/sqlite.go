@@ -0,0 +1,663 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"buchstaben.go/model"
+	// registers the pure Go "sqlite" driver
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteDataSaver stores the games and custom words in a SQLite database.
+// Word lists are still read from files.
+type SQLiteDataSaver struct {
+	DatabasePath string
+	WordLists    []WordListFile
+	db           *sql.DB
+	// saved is the content of the database as last loaded or saved, only
+	// the changes to it are written
+	saved *sqliteState
+}
+
+// sqliteSchema creates the tables. Active games have no ended_position,
+// ended games keep their order in it.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS games (
+	id                   INTEGER PRIMARY KEY,
+	user                 TEXT NOT NULL,
+	language             TEXT NOT NULL DEFAULT '',
+	last_move_timestamp  TEXT NOT NULL DEFAULT '',
+	game_start_timestamp TEXT NOT NULL DEFAULT '',
+	game_end_timestamp   TEXT NOT NULL DEFAULT '',
+	letter_overall_value INTEGER NOT NULL DEFAULT 0,
+	rack                 TEXT NOT NULL DEFAULT '',
+	ended_position       INTEGER
+);
+CREATE TABLE IF NOT EXISTS moves (
+	game_id          INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	position         INTEGER NOT NULL,
+	letters          TEXT NOT NULL,
+	words            TEXT NOT NULL,
+	played_by_myself INTEGER NOT NULL,
+	timestamp        TEXT NOT NULL,
+	points           INTEGER NOT NULL,
+	placement_row    INTEGER,
+	placement_col    INTEGER,
+	direction        TEXT,
+	blanks           TEXT,
+	PRIMARY KEY (game_id, position)
+);
+CREATE TABLE IF NOT EXISTS tiles (
+	game_id        INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	position       INTEGER NOT NULL,
+	letter         TEXT NOT NULL,
+	original_count INTEGER NOT NULL,
+	current_count  INTEGER NOT NULL,
+	value          INTEGER NOT NULL,
+	PRIMARY KEY (game_id, position)
+);
+CREATE TABLE IF NOT EXISTS board_tiles (
+	game_id  INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	row      INTEGER NOT NULL,
+	col      INTEGER NOT NULL,
+	letter   TEXT NOT NULL,
+	blank    INTEGER NOT NULL,
+	PRIMARY KEY (game_id, position)
+);
+CREATE TABLE IF NOT EXISTS events (
+	game_id  INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	type     TEXT NOT NULL,
+	event    TEXT NOT NULL,
+	PRIMARY KEY (game_id, position)
+);
+CREATE TABLE IF NOT EXISTS custom_words (
+	position  INTEGER PRIMARY KEY,
+	word      TEXT NOT NULL,
+	category  TEXT NOT NULL,
+	language  TEXT NOT NULL DEFAULT '',
+	timestamp TEXT NOT NULL
+);
+`
+
+// sqliteMigrations upgrade databases created with an older schema, the
+// user_version of the database counts the migrations applied. New databases
+// are created with the first schema and upgraded by all of them.
+var sqliteMigrations = []string{
+	// running scores and the result of ended games
+	`ALTER TABLE games ADD COLUMN my_score INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE games ADD COLUMN opponent_score INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE games ADD COLUMN result TEXT;
+	UPDATE games SET
+		my_score = (SELECT COALESCE(SUM(points), 0) FROM moves WHERE moves.game_id = games.id AND played_by_myself = 1),
+		opponent_score = (SELECT COALESCE(SUM(points), 0) FROM moves WHERE moves.game_id = games.id AND played_by_myself = 0);`,
+	// games are identified by an id instead of the username
+	`ALTER TABLE games ADD COLUMN game_id TEXT NOT NULL DEFAULT '';
+	UPDATE games SET game_id = lower(hex(randomblob(8))) WHERE game_id = '';`,
+	// the event logs are keyed by the game id and only appended to, they
+	// survive the games rows being written again
+	`CREATE TABLE game_events (
+		game_id  TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		type     TEXT NOT NULL,
+		event    TEXT NOT NULL,
+		PRIMARY KEY (game_id, position)
+	);
+	INSERT INTO game_events (game_id, position, type, event)
+		SELECT games.game_id, events.position, events.type, events.event FROM events JOIN games ON games.id = events.game_id;
+	DROP TABLE events;`,
+	// games are written one at a time by their id
+	`CREATE UNIQUE INDEX games_game_id ON games (game_id);`,
+}
+
+// migrateSQLite applies the migrations the database has not seen yet, each
+// in its own transaction.
+func migrateSQLite(db *sql.DB, version int) error {
+	for i := version; i < len(sqliteMigrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqliteMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("database migration %d failed: %w", i+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// open opens the database and creates the tables on first use.
+func (sds *SQLiteDataSaver) open() (*sql.DB, error) {
+	if sds.db != nil {
+		return sds.db, nil
+	}
+	db, err := sql.Open("sqlite", sds.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	// the database is written by one request at a time
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		db.Close()
+		return nil, err
+	}
+	// the first schema is only created on a database without migrations,
+	// later ones may have dropped its tables
+	if version == 0 {
+		if _, err := db.Exec(sqliteSchema); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create tables: %w", err)
+		}
+	}
+	if err := migrateSQLite(db, version); err != nil {
+		db.Close()
+		return nil, err
+	}
+	sds.db = db
+	return db, nil
+}
+
+// Close closes the database.
+func (sds *SQLiteDataSaver) Close() error {
+	if sds.db == nil {
+		return nil
+	}
+	err := sds.db.Close()
+	sds.db = nil
+	return err
+}
+
+// SaveGamesToFile writes the games and custom words which changed since they
+// were last loaded or saved in a single transaction. The event logs are only
+// appended to.
+func (sds *SQLiteDataSaver) SaveGamesToFile() error {
+	fmt.Println("Saving games to database...")
+
+	db, err := sds.open()
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := savePersistence(tx, model.GlobalPersistence, sds.saved); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	sds.saved = newSQLiteState(model.GlobalPersistence)
+	return nil
+}
+
+// sqliteState is the content of the database as last loaded or saved.
+type sqliteState struct {
+	games       map[string]storedGame
+	customWords []model.CustomWord
+}
+
+// storedGame is a game as it is stored, ended games keep their order in
+// endedPosition.
+type storedGame struct {
+	game          model.UserGame
+	endedPosition *int
+}
+
+func newSQLiteState(persistence model.GlobalPersistenceStruct) *sqliteState {
+	state := &sqliteState{
+		games:       storedGames(persistence),
+		customWords: append([]model.CustomWord{}, persistence.CustomWords...),
+	}
+	// the slices are copied, the services may reuse their arrays
+	for gameID, stored := range state.games {
+		stored.game.PlayedMoves = append([]model.PlayedMove{}, stored.game.PlayedMoves...)
+		stored.game.Board = append(model.Board{}, stored.game.Board...)
+		stored.game.LettersPlaySet = append([]model.LetterPlaySet{}, stored.game.LettersPlaySet...)
+		state.games[gameID] = stored
+	}
+	return state
+}
+
+func storedGames(persistence model.GlobalPersistenceStruct) map[string]storedGame {
+	games := make(map[string]storedGame, len(persistence.Games)+len(persistence.EndedGames))
+	for gameID, game := range persistence.Games {
+		game.ID = gameID
+		games[gameID] = storedGame{game: game}
+	}
+	for i, game := range persistence.EndedGames {
+		position := i
+		games[game.ID] = storedGame{game: game, endedPosition: &position}
+	}
+	return games
+}
+
+// savePersistence deletes the games which are gone and writes the games and
+// custom words which differ from the saved state. Without a saved state
+// everything is written.
+func savePersistence(tx *sql.Tx, persistence model.GlobalPersistenceStruct, saved *sqliteState) error {
+	games := storedGames(persistence)
+	rows, err := tx.Query("SELECT game_id FROM games")
+	if err != nil {
+		return err
+	}
+	deleted := []string{}
+	for rows.Next() {
+		var gameID string
+		if err := rows.Scan(&gameID); err != nil {
+			rows.Close()
+			return err
+		}
+		if _, exists := games[gameID]; !exists {
+			deleted = append(deleted, gameID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// the rows of the other tables and the log go with the game
+	for _, gameID := range deleted {
+		if _, err := tx.Exec("DELETE FROM games WHERE game_id = ?", gameID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM game_events WHERE game_id = ?", gameID); err != nil {
+			return err
+		}
+	}
+
+	for gameID, current := range games {
+		var previous *storedGame
+		if saved != nil {
+			if stored, exists := saved.games[gameID]; exists {
+				if reflect.DeepEqual(stored, current) {
+					continue
+				}
+				previous = &stored
+			}
+		}
+		if err := saveGame(tx, current, previous); err != nil {
+			return err
+		}
+	}
+
+	if saved != nil && reflect.DeepEqual(saved.customWords, persistence.CustomWords) {
+		return nil
+	}
+	if _, err := tx.Exec("DELETE FROM custom_words"); err != nil {
+		return err
+	}
+	for i, customWord := range persistence.CustomWords {
+		if _, err := tx.Exec(
+			"INSERT INTO custom_words (position, word, category, language, timestamp) VALUES (?, ?, ?, ?, ?)",
+			i, customWord.Word, customWord.Category, customWord.Language, customWord.Timestamp,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveGame inserts or updates the row of the game. Moves and board tiles
+// are appended if the previously stored ones are still the first of them,
+// otherwise they are written again like the tiles.
+func saveGame(tx *sql.Tx, stored storedGame, previous *storedGame) error {
+	game := stored.game
+	var gameResult sql.NullString
+	if game.Result != nil {
+		encoded, err := json.Marshal(game.Result)
+		if err != nil {
+			return err
+		}
+		gameResult = sql.NullString{String: string(encoded), Valid: true}
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO games (user, language, last_move_timestamp, game_start_timestamp, game_end_timestamp,
+			letter_overall_value, rack, ended_position, my_score, opponent_score, result, game_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (game_id) DO UPDATE SET user = excluded.user, language = excluded.language,
+			last_move_timestamp = excluded.last_move_timestamp, game_start_timestamp = excluded.game_start_timestamp,
+			game_end_timestamp = excluded.game_end_timestamp, letter_overall_value = excluded.letter_overall_value,
+			rack = excluded.rack, ended_position = excluded.ended_position, my_score = excluded.my_score,
+			opponent_score = excluded.opponent_score, result = excluded.result`,
+		game.User, game.Language, game.LastMoveTimestamp, game.GameStartTimestamp, game.GameEndTimestamp,
+		game.LetterOverAllValue, game.Rack, stored.endedPosition, game.MyScore, game.OpponentScore, gameResult, game.ID,
+	); err != nil {
+		return fmt.Errorf("failed to save game of %s: %w", game.User, err)
+	}
+	var gameID int64
+	if err := tx.QueryRow("SELECT id FROM games WHERE game_id = ?", game.ID).Scan(&gameID); err != nil {
+		return err
+	}
+
+	var previousMoves []model.PlayedMove
+	var previousBoard model.Board
+	if previous != nil {
+		previousMoves, previousBoard = previous.game.PlayedMoves, previous.game.Board
+	}
+	kept, err := keepRows(tx, "moves", gameID, previousMoves, game.PlayedMoves)
+	if err != nil {
+		return err
+	}
+	for i := kept; i < len(game.PlayedMoves); i++ {
+		move := game.PlayedMoves[i]
+		words, err := json.Marshal(move.Words)
+		if err != nil {
+			return err
+		}
+		var row, col sql.NullInt64
+		var direction, blanks sql.NullString
+		if move.Placement != nil {
+			row = sql.NullInt64{Int64: int64(move.Placement.Row), Valid: true}
+			col = sql.NullInt64{Int64: int64(move.Placement.Col), Valid: true}
+			direction = sql.NullString{String: move.Placement.Direction, Valid: true}
+			encoded, err := json.Marshal(move.Placement.Blanks)
+			if err != nil {
+				return err
+			}
+			blanks = sql.NullString{String: string(encoded), Valid: true}
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO moves (game_id, position, letters, words, played_by_myself, timestamp, points,
+				placement_row, placement_col, direction, blanks) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			gameID, i, move.Letters, string(words), move.PlayedByMyself, move.Timestamp, move.Points,
+			row, col, direction, blanks,
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM tiles WHERE game_id = ?", gameID); err != nil {
+		return err
+	}
+	for i, l := range game.LettersPlaySet {
+		if _, err := tx.Exec(
+			"INSERT INTO tiles (game_id, position, letter, original_count, current_count, value) VALUES (?, ?, ?, ?, ?, ?)",
+			gameID, i, l.Letter, l.OriginalCount, l.CurrentCount, l.Value,
+		); err != nil {
+			return err
+		}
+	}
+	kept, err = keepRows(tx, "board_tiles", gameID, previousBoard, game.Board)
+	if err != nil {
+		return err
+	}
+	for i := kept; i < len(game.Board); i++ {
+		tile := game.Board[i]
+		if _, err := tx.Exec(
+			"INSERT INTO board_tiles (game_id, position, row, col, letter, blank) VALUES (?, ?, ?, ?, ?, ?)",
+			gameID, i, tile.Row, tile.Col, tile.Letter, tile.Blank,
+		); err != nil {
+			return err
+		}
+	}
+	return appendEvents(tx, game)
+}
+
+// keepRows returns the number of stored rows of the game which are kept, all
+// previous ones if they are the first of the current ones. The other rows of
+// the game are deleted from the table.
+func keepRows[T any](tx *sql.Tx, table string, gameID int64, previous, current []T) (int, error) {
+	kept := 0
+	if len(previous) > 0 && len(previous) <= len(current) && reflect.DeepEqual(previous, current[:len(previous)]) {
+		kept = len(previous)
+	}
+	if _, err := tx.Exec("DELETE FROM "+table+" WHERE game_id = ? AND position >= ?", gameID, kept); err != nil {
+		return 0, err
+	}
+	return kept, nil
+}
+
+// appendEvents stores the events of the game's log which are not stored
+// yet. Like checkEventLogs it refuses a log which does not start with the
+// stored events, as events were lost or changed.
+func appendEvents(tx *sql.Tx, game model.UserGame) error {
+	rows, err := tx.Query("SELECT event FROM game_events WHERE game_id = ? ORDER BY position", game.ID)
+	if err != nil {
+		return err
+	}
+	stored := []string{}
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			rows.Close()
+			return err
+		}
+		stored = append(stored, encoded)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// events are compared in their stored form, decoding them again does
+	// not give back empty slices
+	events := make([]string, len(game.Events))
+	for i, event := range game.Events {
+		encoded, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		events[i] = string(encoded)
+	}
+	if len(events) < len(stored) || !slices.Equal(events[:len(stored)], stored) {
+		return fmt.Errorf("the event log of game %s was changed, events can only be appended", game.ID)
+	}
+	for i := len(stored); i < len(events); i++ {
+		if _, err := tx.Exec(
+			"INSERT INTO game_events (game_id, position, type, event) VALUES (?, ?, ?, ?)",
+			game.ID, i, game.Events[i].Type, events[i],
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadGamesFromFile reads the games and custom words of the database, an
+// empty database holds no games. Games are rebuilt out of their event logs.
+func (sds *SQLiteDataSaver) LoadGamesFromFile() error {
+	fmt.Println("Loading games from database...")
+
+	db, err := sds.open()
+	if err != nil {
+		return err
+	}
+	persistence := model.GlobalPersistenceStruct{
+		SchemaVersion: CurrentSchemaVersion,
+		Games:         make(map[string]model.UserGame),
+		EndedGames:    []model.UserGame{},
+		CustomWords:   []model.CustomWord{},
+	}
+
+	rows, err := db.Query(`SELECT id, user, language, last_move_timestamp, game_start_timestamp, game_end_timestamp,
+		letter_overall_value, rack, ended_position, my_score, opponent_score, result, game_id
+		FROM games ORDER BY ended_position, id`)
+	if err != nil {
+		return err
+	}
+	type storedGame struct {
+		id    int64
+		ended bool
+		game  model.UserGame
+	}
+	games := []storedGame{}
+	for rows.Next() {
+		var stored storedGame
+		var endedPosition sql.NullInt64
+		var result sql.NullString
+		game := &stored.game
+		if err := rows.Scan(&stored.id, &game.User, &game.Language, &game.LastMoveTimestamp, &game.GameStartTimestamp,
+			&game.GameEndTimestamp, &game.LetterOverAllValue, &game.Rack, &endedPosition,
+			&game.MyScore, &game.OpponentScore, &result, &game.ID); err != nil {
+			rows.Close()
+			return err
+		}
+		if result.Valid {
+			game.Result = &model.GameResult{}
+			if err := json.Unmarshal([]byte(result.String), game.Result); err != nil {
+				rows.Close()
+				return fmt.Errorf("result of the game of %s is not valid: %w", game.User, err)
+			}
+		}
+		stored.ended = endedPosition.Valid
+		games = append(games, stored)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, stored := range games {
+		game := stored.game
+		if err := loadGameDetails(db, stored.id, &game); err != nil {
+			return fmt.Errorf("failed to load game of %s: %w", game.User, err)
+		}
+		if stored.ended {
+			persistence.EndedGames = append(persistence.EndedGames, game)
+		} else {
+			persistence.Games[game.ID] = game
+		}
+	}
+
+	customWords, err := db.Query("SELECT word, category, language, timestamp FROM custom_words ORDER BY position")
+	if err != nil {
+		return err
+	}
+	defer customWords.Close()
+	for customWords.Next() {
+		var customWord model.CustomWord
+		if err := customWords.Scan(&customWord.Word, &customWord.Category, &customWord.Language, &customWord.Timestamp); err != nil {
+			return err
+		}
+		persistence.CustomWords = append(persistence.CustomWords, customWord)
+	}
+	if err := customWords.Err(); err != nil {
+		return err
+	}
+
+	// the state is taken before the replay, so games whose stored state is
+	// behind their log are written again with the next save
+	sds.saved = newSQLiteState(persistence)
+	replayGames(&persistence)
+	model.GlobalPersistence = persistence
+	return nil
+}
+
+// loadGameDetails reads the rows of the game from the other tables. Every
+// query is closed before the next one, the database has a single connection.
+func loadGameDetails(db *sql.DB, gameID int64, game *model.UserGame) error {
+	loaders := []func(*sql.DB, int64, *model.UserGame) error{loadMoves, loadTiles, loadBoard, loadEvents}
+	for _, loader := range loaders {
+		if err := loader(db, gameID, game); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadMoves(db *sql.DB, gameID int64, game *model.UserGame) error {
+	game.PlayedMoves = []model.PlayedMove{}
+	rows, err := db.Query(`SELECT letters, words, played_by_myself, timestamp, points,
+		placement_row, placement_col, direction, blanks FROM moves WHERE game_id = ? ORDER BY position`, gameID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var move model.PlayedMove
+		var words string
+		var row, col sql.NullInt64
+		var direction, blanks sql.NullString
+		if err := rows.Scan(&move.Letters, &words, &move.PlayedByMyself, &move.Timestamp, &move.Points,
+			&row, &col, &direction, &blanks); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(words), &move.Words); err != nil {
+			return err
+		}
+		if direction.Valid {
+			move.Placement = &model.Placement{Row: int(row.Int64), Col: int(col.Int64), Direction: direction.String}
+			if err := json.Unmarshal([]byte(blanks.String), &move.Placement.Blanks); err != nil {
+				return err
+			}
+		}
+		game.PlayedMoves = append(game.PlayedMoves, move)
+	}
+	return rows.Err()
+}
+
+func loadTiles(db *sql.DB, gameID int64, game *model.UserGame) error {
+	rows, err := db.Query("SELECT letter, original_count, current_count, value FROM tiles WHERE game_id = ? ORDER BY position", gameID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var l model.LetterPlaySet
+		if err := rows.Scan(&l.Letter, &l.OriginalCount, &l.CurrentCount, &l.Value); err != nil {
+			return err
+		}
+		game.LettersPlaySet = append(game.LettersPlaySet, l)
+	}
+	return rows.Err()
+}
+
+func loadBoard(db *sql.DB, gameID int64, game *model.UserGame) error {
+	game.Board = model.Board{}
+	rows, err := db.Query("SELECT row, col, letter, blank FROM board_tiles WHERE game_id = ? ORDER BY position", gameID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tile model.PlacedTile
+		if err := rows.Scan(&tile.Row, &tile.Col, &tile.Letter, &tile.Blank); err != nil {
+			return err
+		}
+		game.Board = append(game.Board, tile)
+	}
+	return rows.Err()
+}
+
+func loadEvents(db *sql.DB, gameID int64, game *model.UserGame) error {
+	rows, err := db.Query("SELECT event FROM game_events WHERE game_id = ? ORDER BY position", game.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return err
+		}
+		var event model.GameEvent
+		if err := json.Unmarshal([]byte(encoded), &event); err != nil {
+			return err
+		}
+		game.Events = append(game.Events, event)
+	}
+	return rows.Err()
+}
+
+func (sds *SQLiteDataSaver) LoadWordListFromFile() error {
+	return loadWordLists(sds.WordLists)
+}
@@ -0,0 +1,266 @@
+package persistence
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// gameOf returns the game against the user, the games are keyed by id.
+func gameOf(games map[string]model.UserGame, user string) model.UserGame {
+	for _, game := range games {
+		if game.User == user {
+			return game
+		}
+	}
+	return model.UserGame{}
+}
+
+func sqliteTestPersistence() model.GlobalPersistenceStruct {
+	move := model.PlayedMove{
+		Letters:        "hut",
+		Words:          []string{"hut"},
+		PlayedByMyself: true,
+		Timestamp:      "2024-01-01 10:01:00",
+		Points:         4,
+		Placement:      &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal, Blanks: []int{1}},
+	}
+	played, _ := logic.ReplayEvents(model.UserGame{ID: "g1", User: "testuser"}, []model.GameEvent{
+		{Type: model.EventCreated, Timestamp: "2024-01-01 10:00:00", Language: "de"},
+		{Type: model.EventRackSet, Timestamp: "2024-01-01 10:00:30", Rack: "h*txy"},
+		{Type: model.EventMovePlayed, Timestamp: "2024-01-01 10:01:00", Move: &move},
+		{Type: model.EventMovePlayed, Timestamp: "2024-01-01 10:02:00", Move: &model.PlayedMove{Letters: "ab", Words: []string{"ab"}, Timestamp: "2024-01-01 10:02:00"}},
+	}, -1)
+	return model.GlobalPersistenceStruct{
+		SchemaVersion: CurrentSchemaVersion,
+		Games: map[string]model.UserGame{
+			"g1": played,
+			// stored before the event log was kept
+			"g2": {
+				ID:                 "g2",
+				User:               "legacy",
+				Language:           "de",
+				LettersPlaySet:     []model.LetterPlaySet{{Letter: "a", OriginalCount: 5, CurrentCount: 4, Value: 1}},
+				LastMoveTimestamp:  "2024-01-01 10:01:00",
+				GameStartTimestamp: "2024-01-01 10:00:00",
+				LetterOverAllValue: 4,
+				PlayedMoves:        []model.PlayedMove{move, {Letters: "ab", Words: []string{"ab"}, Timestamp: "2024-01-01 10:02:00"}},
+				Board:              model.Board{{Row: 7, Col: 6, Letter: "h"}, {Row: 7, Col: 7, Letter: "u", Blank: true}},
+				Rack:               "xy",
+				MyScore:            4,
+			},
+		},
+		EndedGames: []model.UserGame{
+			{ID: "e2", User: "second", GameStartTimestamp: "2023-01-01 10:00:00", GameEndTimestamp: "2023-01-02 10:00:00", PlayedMoves: []model.PlayedMove{}, Board: model.Board{},
+				Result: &model.GameResult{MyScore: 3, OpponentScore: -3, Spread: 6, Outcome: model.OutcomeWon, MyRackAdjustment: 3, OpponentRackAdjustment: -3}},
+			{ID: "e1", User: "first", GameStartTimestamp: "2022-01-01 10:00:00", GameEndTimestamp: "2022-01-02 10:00:00", PlayedMoves: []model.PlayedMove{}, Board: model.Board{}},
+		},
+		CustomWords: []model.CustomWord{{Word: "ob", Category: model.CategoryInvalid, Timestamp: "2024-01-01 10:00:00"}},
+	}
+}
+
+func TestSQLiteDataSaver(t *testing.T) {
+	saver := &SQLiteDataSaver{DatabasePath: filepath.Join(t.TempDir(), "games.db")}
+	defer saver.Close()
+
+	// An empty database holds no games
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	err := saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	assert.Empty(t, model.GlobalPersistence.Games)
+	assert.NotNil(t, model.GlobalPersistence.Games)
+
+	expected := sqliteTestPersistence()
+	model.GlobalPersistence = sqliteTestPersistence()
+	err = saver.SaveGamesToFile()
+	assert.NoError(t, err)
+
+	// Saving again without changes keeps the stored games
+	err = saver.SaveGamesToFile()
+	assert.NoError(t, err)
+
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	err = saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, model.GlobalPersistence, "Expected the games to survive a round trip")
+
+	// A new connection reads the same data
+	saver.Close()
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	err = saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	assert.Len(t, model.GlobalPersistence.EndedGames, 2)
+	assert.Equal(t, "second", model.GlobalPersistence.EndedGames[0].User, "Expected ended games to keep their order")
+
+	// the stored state is only a cache of the event log
+	_, err = saver.db.Exec("UPDATE games SET my_score = 99, rack = '' WHERE game_id = 'g1'; DELETE FROM board_tiles")
+	assert.NoError(t, err)
+	err = saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Games["g1"], model.GlobalPersistence.Games["g1"], "Expected the game to be rebuilt out of its events")
+	assert.Empty(t, model.GlobalPersistence.Games["g2"].Board, "Expected a game without events to keep its stored state")
+
+	// the event log is only appended to
+	game := model.GlobalPersistence.Games["g1"]
+	game.Events = game.Events[:2]
+	model.GlobalPersistence.Games["g1"] = game
+	assert.Error(t, saver.SaveGamesToFile(), "Expected error for a shortened event log")
+	game, _ = logic.RecordEvent(expected.Games["g1"], model.GameEvent{Type: model.EventRackSet, Rack: "xyz"})
+	model.GlobalPersistence.Games["g1"] = game
+	assert.NoError(t, saver.SaveGamesToFile())
+	changed := game
+	changed.Events = append([]model.GameEvent{}, game.Events...)
+	changed.Events[1].Rack = "abc"
+	model.GlobalPersistence.Games["g1"] = changed
+	assert.EqualError(t, saver.SaveGamesToFile(), "the event log of game g1 was changed, events can only be appended",
+		"Expected error for a changed event")
+	delete(model.GlobalPersistence.Games, "g1")
+	assert.NoError(t, saver.SaveGamesToFile())
+	var events int
+	assert.NoError(t, saver.db.QueryRow("SELECT COUNT(*) FROM game_events").Scan(&events))
+	assert.Zero(t, events, "Expected the log of a deleted game to be removed")
+
+	invalidSaver := &SQLiteDataSaver{DatabasePath: "/nonexistent/directory/games.db"}
+	assert.Error(t, invalidSaver.LoadGamesFromFile(), "Should return error for invalid database path")
+}
+
+func TestSQLiteDataSaver_WritesChanges(t *testing.T) {
+	saver := &SQLiteDataSaver{DatabasePath: filepath.Join(t.TempDir(), "games.db")}
+	defer saver.Close()
+	model.GlobalPersistence = sqliteTestPersistence()
+	assert.NoError(t, saver.SaveGamesToFile())
+
+	// rows changed behind the saver's back show which rows are written again
+	_, err := saver.db.Exec(`UPDATE games SET rack = 'untouched' WHERE game_id = 'g2';
+		UPDATE moves SET letters = 'untouched' WHERE position = 0`)
+	assert.NoError(t, err)
+
+	game, err := logic.RecordEvent(model.GlobalPersistence.Games["g1"], model.GameEvent{Type: model.EventMovePlayed,
+		Move: &model.PlayedMove{Letters: "s", Placement: &model.Placement{Row: 7, Col: 9, Direction: model.Horizontal}}})
+	assert.NoError(t, err)
+	model.GlobalPersistence.Games["g1"] = game
+	assert.NoError(t, saver.SaveGamesToFile())
+
+	var rack string
+	assert.NoError(t, saver.db.QueryRow("SELECT rack FROM games WHERE game_id = 'g2'").Scan(&rack))
+	assert.Equal(t, "untouched", rack, "Expected an unchanged game not to be written")
+	rows, err := saver.db.Query(`SELECT letters FROM moves JOIN games ON games.id = moves.game_id
+		WHERE games.game_id = 'g1' ORDER BY position`)
+	assert.NoError(t, err)
+	letters := []string{}
+	for rows.Next() {
+		var move string
+		assert.NoError(t, rows.Scan(&move))
+		letters = append(letters, move)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"untouched", "ab", "s"}, letters, "Expected the new move to be appended")
+
+	// a deleted move writes the moves of the game again
+	game, err = logic.RecordEvent(game, model.GameEvent{Type: model.EventMoveDeleted, Index: 2})
+	assert.NoError(t, err)
+	model.GlobalPersistence.Games["g1"] = game
+	assert.NoError(t, saver.SaveGamesToFile())
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	saver.Close()
+	assert.NoError(t, saver.LoadGamesFromFile())
+	assert.Len(t, model.GlobalPersistence.Games["g1"].PlayedMoves, 2)
+	var moves int
+	assert.NoError(t, saver.db.QueryRow("SELECT COUNT(*) FROM moves WHERE letters = 'untouched'").Scan(&moves))
+	assert.Equal(t, 1, moves, "Expected only the moves of the edited game to be written again")
+}
+
+func TestReadGamesFile(t *testing.T) {
+	path := createTempFile(t, `{
+		"games": {"old": {"user": "old", "played_moves": [{"letters": "umtue", "word": "umtue", "points": 14}]}},
+		"ended_games": [{"user": "ended", "played_moves": [{"letters": "ab", "word": "", "words": ["ab"]}]}]
+	}`)
+
+	persistence, err := ReadGamesFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"umtue"}, gameOf(persistence.Games, "old").PlayedMoves[0].Words, "Expected the old word field to be upgraded")
+	assert.Equal(t, []string{"ab"}, persistence.EndedGames[0].PlayedMoves[0].Words)
+
+	_, err = ReadGamesFile(createTempFile(t, "{"))
+	assert.Error(t, err)
+}
+
+func TestMergePersistence(t *testing.T) {
+	old := model.GlobalPersistenceStruct{
+		Games: map[string]model.UserGame{
+			"ended":   {User: "ended", GameStartTimestamp: "2024-01-01 10:00:00"},
+			"running": {User: "running", GameStartTimestamp: "2024-01-01 10:00:00"},
+		},
+		CustomWords: []model.CustomWord{{Word: "ob"}},
+	}
+	current := model.GlobalPersistenceStruct{
+		Games: map[string]model.UserGame{
+			"running": {User: "running", GameStartTimestamp: "2024-01-01 10:00:00", Rack: "new"},
+		},
+		EndedGames:  []model.UserGame{{User: "ended", GameStartTimestamp: "2024-01-01 10:00:00"}},
+		CustomWords: []model.CustomWord{{Word: "ob"}, {Word: "bo"}},
+	}
+
+	merged := MergePersistence(old, current, current)
+	assert.Len(t, merged.Games, 1, "Expected games ended later to be dropped")
+	assert.Equal(t, "new", merged.Games["running"].Rack, "Expected later files to win")
+	assert.Len(t, merged.EndedGames, 1)
+	assert.Len(t, merged.CustomWords, 2)
+}
+
+func TestMigrateToSQLite(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "games-old.json")
+	err := os.WriteFile(oldPath, []byte(`{"games": {"old": {"user": "old", "played_moves": [{"letters": "ab", "word": "ab"}]}}, "ended_games": []}`), 0644)
+	assert.NoError(t, err)
+	saver := &SQLiteDataSaver{DatabasePath: filepath.Join(dir, "games.db")}
+	defer saver.Close()
+
+	migrated, err := MigrateToSQLite(saver, oldPath, filepath.Join(dir, "missing.json"))
+	assert.NoError(t, err)
+	assert.Len(t, migrated.Games, 1)
+
+	model.GlobalPersistence = model.GlobalPersistenceStruct{}
+	err = saver.LoadGamesFromFile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ab"}, gameOf(model.GlobalPersistence.Games, "old").PlayedMoves[0].Words)
+
+	_, err = MigrateToSQLite(saver, oldPath)
+	assert.Error(t, err, "Expected the migration to run only once")
+}
+
+func TestMigrateSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.db")
+
+	// a database of the first schema
+	db, err := sql.Open("sqlite", path)
+	assert.NoError(t, err)
+	_, err = db.Exec(sqliteSchema)
+	assert.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO games (id, user) VALUES (1, 'testuser');
+		INSERT INTO moves (game_id, position, letters, words, played_by_myself, timestamp, points)
+		VALUES (1, 0, 'hut', '["hut"]', 1, '', 4), (1, 1, 'ab', '["ab"]', 0, '', 7), (1, 2, 'ob', '["ob"]', 1, '', 5)`)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+
+	saver := &SQLiteDataSaver{DatabasePath: path}
+	defer saver.Close()
+	assert.NoError(t, saver.LoadGamesFromFile())
+	game := gameOf(model.GlobalPersistence.Games, "testuser")
+	assert.NotEmpty(t, game.ID, "Expected the migration to give the game an id")
+	assert.Equal(t, 9, game.MyScore, "Expected the scores to be totalled by the migration")
+	assert.Equal(t, 7, game.OpponentScore)
+
+	var version int
+	assert.NoError(t, saver.db.QueryRow("PRAGMA user_version").Scan(&version))
+	assert.Equal(t, len(sqliteMigrations), version)
+
+	// migrations are applied once
+	assert.NoError(t, saver.Close())
+	assert.NoError(t, saver.LoadGamesFromFile())
+}
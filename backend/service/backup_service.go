@@ -0,0 +1,40 @@
+package service
+
+import (
+	"fmt"
+
+	"buchstaben.go/model"
+	"buchstaben.go/persistence"
+)
+
+func (ds *DataService) backupRestorer() (persistence.BackupRestorer, error) {
+	restorer, ok := ds.Saver.(persistence.BackupRestorer)
+	if !ok {
+		return nil, fmt.Errorf("the storage does not keep backups")
+	}
+	return restorer, nil
+}
+
+// ListBackups returns the names of the backups of the games, newest first.
+func (ds *DataService) ListBackups() ([]string, error) {
+	restorer, err := ds.backupRestorer()
+	if err != nil {
+		return nil, err
+	}
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	return restorer.ListBackups()
+}
+
+// RestoreBackup rolls all games and custom words back to the backup.
+func (ds *DataService) RestoreBackup(name string) error {
+	restorer, err := ds.backupRestorer()
+	if err != nil {
+		return err
+	}
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	return restorer.RestoreBackup(name)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+	"buchstaben.go/persistence"
+)
+
+// benchmarkDataDir is the data directory of the server seen from this package.
+const benchmarkDataDir = "../../data"
+
+var benchmarkRacks = []string{"erstanl", "quizeb*", "aeiou**", "übelkrs"}
+
+// setupBenchmarkEnvironment loads the word lists the server loads.
+func setupBenchmarkEnvironment(b *testing.B) *DataService {
+	b.Helper()
+
+	service, _ := setupTestEnvironment()
+	saver := &persistence.FileDataSaver{WordLists: persistence.WordLists(benchmarkDataDir)}
+	if err := saver.LoadWordListFromFile(); err != nil {
+		b.Fatalf("Failed to load word lists: %v", err)
+	}
+	if err := checkDictionary(logic.DefaultLanguage); err != nil {
+		b.Skipf("word list not available: %v", err)
+	}
+	if err := service.IndexDictionaries(); err != nil {
+		b.Fatalf("Failed to index word list: %v", err)
+	}
+	return service
+}
+
+// findWordsByScan is the search as it was before the word lists were
+// indexed: every word is checked against the rack on every request.
+func findWordsByScan(letters string) []model.WordCount {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	unfilteredWordCounts := make(map[string]int)
+	for word := range dictionary(logic.DefaultLanguage) {
+		if len(word) > 15 || len(word) < 2 {
+			continue
+		}
+		if !regexp.MustCompile(`^[a-zA-Z-]+$`).MatchString(word) {
+			continue
+		}
+		unfilteredWordCounts[word] = 0
+	}
+
+	wordsCount := make([]model.WordCount, 0, len(unfilteredWordCounts))
+	for word := range unfilteredWordCounts {
+		if !buildWordOutOfLetters(word, letters) {
+			continue
+		}
+		wordsCount = append(wordsCount, model.WordCount{Word: word})
+	}
+	return sortWordCounts(wordsCount)
+}
+
+func BenchmarkFindWords(b *testing.B) {
+	service := setupBenchmarkEnvironment(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.FindWords(benchmarkRacks[i%len(benchmarkRacks)], "", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindWordsByScan(b *testing.B) {
+	setupBenchmarkEnvironment(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		findWordsByScan(benchmarkRacks[i%len(benchmarkRacks)])
+	}
+}
+
+func BenchmarkIndexDictionaries(b *testing.B) {
+	setupBenchmarkEnvironment(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service := &DataService{Saver: &MockDataSaver{}}
+		if err := service.IndexDictionaries(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,33 +2,53 @@ package service
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"buchstaben.go/logic"
 	"buchstaben.go/model"
 )
 
-// Add these functions to your DataService struct
-
+// AddCustomWords stores the words normalized like the imported words. Words
+// already stored in the language are refused.
 func (ds *DataService) AddCustomWords(newWords model.CustomWords) error {
+	if err := checkCustomWords(newWords); err != nil {
+		return err
+	}
+
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	// Check if word already exists
-	for _, word := range model.GlobalPersistence.CustomWords {
-		for _, newWord := range newWords.Words {
-			if word.Word == newWord {
-				return fmt.Errorf("word '%s' already exists", newWord)
-			}
+	language := logic.LanguageOrDefault(newWords.Language)
+	stored := make(map[string]bool)
+	for _, customWord := range model.GlobalPersistence.CustomWords {
+		if logic.LanguageOrDefault(customWord.Language) == language {
+			stored[normalizeCustomWord(customWord.Word)] = true
 		}
 	}
 
+	words := []string{}
 	for _, newWord := range newWords.Words {
+		word := normalizeCustomWord(newWord)
+		if word == "" {
+			continue
+		}
+		// Check if word already exists
+		if stored[word] {
+			return fmt.Errorf("word '%s' already exists", word)
+		}
+		stored[word] = true
+		words = append(words, word)
+	}
+
+	for _, word := range words {
 		addWord := model.CustomWord{
-			Word:      newWord,
+			Word:      word,
 			Category:  newWords.Category,
+			Language:  newWords.Language,
 			Timestamp: time.Now().Format("2006-01-02 15:04:05"),
 		}
-		fmt.Printf("addWord: %+v\n", addWord)
 		model.GlobalPersistence.CustomWords = append(model.GlobalPersistence.CustomWords, addWord)
 	}
 	return ds.Saver.SaveGamesToFile()
@@ -41,13 +61,17 @@ func (ds *DataService) GetCustomWords() []model.CustomWord {
 	return model.GlobalPersistence.CustomWords
 }
 
-func (ds *DataService) DeleteCustomWord(word string) error {
+// DeleteCustomWord removes the word of the language, which is normalized like
+// the stored words.
+func (ds *DataService) DeleteCustomWord(word, language string) error {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
+	word = normalizeCustomWord(word)
+	language = logic.LanguageOrDefault(language)
 	found := false
 	for i, w := range model.GlobalPersistence.CustomWords {
-		if w.Word == word {
+		if normalizeCustomWord(w.Word) == word && logic.LanguageOrDefault(w.Language) == language {
 			// Remove the word by slicing
 			model.GlobalPersistence.CustomWords = append(
 				model.GlobalPersistence.CustomWords[:i],
@@ -59,8 +83,134 @@ func (ds *DataService) DeleteCustomWord(word string) error {
 	}
 
 	if !found {
-		return fmt.Errorf("word '%s' not found", word)
+		return fmt.Errorf("word '%s' not found in language %q", word, language)
 	}
 
 	return ds.Saver.SaveGamesToFile()
 }
+
+// GetCustomWordsByCategory returns the custom words of the category sorted
+// by word, limited to the language unless it is empty.
+func (ds *DataService) GetCustomWordsByCategory(category, language string) []model.CustomWord {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	customWords := []model.CustomWord{}
+	for _, customWord := range model.GlobalPersistence.CustomWords {
+		if customWord.Category != category {
+			continue
+		}
+		if language != "" && logic.LanguageOrDefault(customWord.Language) != logic.LanguageOrDefault(language) {
+			continue
+		}
+		customWords = append(customWords, customWord)
+	}
+	sort.Slice(customWords, func(i, j int) bool { return customWords[i].Word < customWords[j].Word })
+	return customWords
+}
+
+// ImportCustomWords adds the words to the category. Words already stored in
+// another category of the language are moved, words already in the category
+// are skipped.
+func (ds *DataService) ImportCustomWords(newWords model.CustomWords) (model.ImportResult, error) {
+	result := model.ImportResult{}
+	if err := checkCustomWords(newWords); err != nil {
+		return result, err
+	}
+	language := logic.LanguageOrDefault(newWords.Language)
+
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	stored := make(map[string]int)
+	for i, customWord := range model.GlobalPersistence.CustomWords {
+		if logic.LanguageOrDefault(customWord.Language) == language {
+			stored[normalizeCustomWord(customWord.Word)] = i
+		}
+	}
+
+	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	for _, newWord := range newWords.Words {
+		word := normalizeCustomWord(newWord)
+		if word == "" {
+			continue
+		}
+		if i, exists := stored[word]; exists {
+			customWord := &model.GlobalPersistence.CustomWords[i]
+			if customWord.Category == newWords.Category {
+				result.Skipped++
+				continue
+			}
+			customWord.Category = newWords.Category
+			customWord.Timestamp = timestamp
+			result.Updated++
+			continue
+		}
+		stored[word] = len(model.GlobalPersistence.CustomWords)
+		model.GlobalPersistence.CustomWords = append(model.GlobalPersistence.CustomWords, model.CustomWord{
+			Word:      word,
+			Category:  newWords.Category,
+			Language:  newWords.Language,
+			Timestamp: timestamp,
+		})
+		result.Imported++
+	}
+
+	if result.Imported == 0 && result.Updated == 0 {
+		return result, nil
+	}
+	return result, ds.Saver.SaveGamesToFile()
+}
+
+// checkCustomWords returns an error if the words have no category or a
+// language without tile set.
+func checkCustomWords(newWords model.CustomWords) error {
+	if newWords.Category == "" {
+		return fmt.Errorf("category is required")
+	}
+	if newWords.Language != "" {
+		if _, err := logic.LoadLettersPlaySetFor(newWords.Language); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeCustomWord is the form custom words are stored and compared in.
+func normalizeCustomWord(word string) string {
+	return logic.NormalizeWord(strings.TrimSpace(word))
+}
+
+// customWordIndex indexes the custom words of the language which are added
+// to the searches, all categories if category is empty. The caller must hold
+// the GamesLock.
+func customWordIndex(language, category string, alphabet []rune) *logic.WordIndex {
+	language = logic.LanguageOrDefault(language)
+	words := []string{}
+	for _, customWord := range model.GlobalPersistence.CustomWords {
+		if logic.LanguageOrDefault(customWord.Language) != language {
+			continue
+		}
+		if customWord.Category == model.CategoryInvalid {
+			continue
+		}
+		if category != "" && customWord.Category != category {
+			continue
+		}
+		words = append(words, customWord.Word)
+	}
+	return logic.NewWordIndex(words, alphabet)
+}
+
+// invalidWords returns the words of the language marked as invalid, to be
+// left out of the searches. The caller must hold the GamesLock.
+func invalidWords(language string) map[string]bool {
+	language = logic.LanguageOrDefault(language)
+	invalid := make(map[string]bool)
+	for _, customWord := range model.GlobalPersistence.CustomWords {
+		if customWord.Category == model.CategoryInvalid && logic.LanguageOrDefault(customWord.Language) == language {
+			invalid[logic.NormalizeWord(customWord.Word)] = true
+		}
+	}
+	return invalid
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// wordIndexes are the search structures built from the word list of a
+// language.
+type wordIndexes struct {
+	words  *logic.WordIndex
+	gaddag *logic.Gaddag
+}
+
+// IndexDictionaries builds the indexes of every loaded word list, so no
+// search has to wait for them.
+func (ds *DataService) IndexDictionaries() error {
+	for language := range model.GlobalDictionaries {
+		fmt.Printf("Indexing %s word list...\n", language)
+		if _, err := ds.indexes(language); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexes returns the indexes of the word list of the language and builds
+// them on first use.
+func (ds *DataService) indexes(language string) (*wordIndexes, error) {
+	ds.indexLock.Lock()
+	defer ds.indexLock.Unlock()
+
+	language = logic.LanguageOrDefault(language)
+	if indexes, exists := ds.wordIndexes[language]; exists {
+		return indexes, nil
+	}
+	lettersPlaySet, err := logic.LoadLettersPlaySetFor(language)
+	if err != nil {
+		return nil, err
+	}
+
+	wordMap := dictionary(language)
+	words := make([]string, 0, len(wordMap))
+	for word := range wordMap {
+		words = append(words, word)
+	}
+	alphabet := logic.Alphabet(lettersPlaySet)
+	indexes := &wordIndexes{
+		words:  logic.NewWordIndex(words, alphabet),
+		gaddag: logic.NewGaddag(words, alphabet),
+	}
+	if ds.wordIndexes == nil {
+		ds.wordIndexes = make(map[string]*wordIndexes)
+	}
+	ds.wordIndexes[language] = indexes
+	return indexes, nil
+}
+
+// dictionary returns the word list of the language, or an empty one if no
+// word list is loaded for it.
+func dictionary(language string) model.WordMap {
+	return model.GlobalDictionaries[logic.LanguageOrDefault(language)]
+}
+
+// checkDictionary returns an error if no word list of the language is
+// loaded, either because none is configured or because its files are
+// missing, so no games are started in it.
+func checkDictionary(language string) error {
+	language = logic.LanguageOrDefault(language)
+	if _, loaded := model.GlobalDictionaries[language]; loaded {
+		return nil
+	}
+	if path, missing := model.GlobalMissingDictionaries[language]; missing {
+		return fmt.Errorf("no word list is loaded for language %q, %s is missing", language, path)
+	}
+	return fmt.Errorf("no word list is loaded for language %q", language)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// ExportGCG returns the active game in the GCG format.
+func (ds *DataService) ExportGCG(gameID string) (model.UserGame, string, error) {
+	game, err := ds.GetGame(gameID)
+	if err != nil {
+		return model.UserGame{}, "", err
+	}
+	gcg, err := logic.ExportGCG(game)
+	return game, gcg, err
+}
+
+// ExportEndedGameGCG returns the ended game in the GCG format.
+func (ds *DataService) ExportEndedGameGCG(gameID string) (model.UserGame, string, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	for _, game := range model.GlobalPersistence.EndedGames {
+		if game.ID == gameID {
+			gcg, err := logic.ExportGCG(game)
+			return game, gcg, err
+		}
+	}
+	return model.UserGame{}, "", fmt.Errorf("ended %w", ErrGameNotFound)
+}
+
+// ImportGCG creates a new active game out of a GCG file, me is my nickname
+// in the file.
+func (ds *DataService) ImportGCG(gcg, me, language string) (model.UserGame, error) {
+	if err := checkDictionary(language); err != nil {
+		return model.UserGame{}, err
+	}
+	game, err := logic.ImportGCG(gcg, me, logic.LanguageOrDefault(language), time.Now().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	game.ID = logic.NewGameID()
+
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	model.GlobalPersistence.Games[game.ID] = game
+	return game, ds.Saver.SaveGamesToFile()
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// DeleteMove removes a played move, its tiles go back into the letter set and
+// my own tiles back on the rack. The board is rebuilt out of the remaining
+// moves, the later ones are scored again.
+func (ds *DataService) DeleteMove(gameID string, index int) (model.UserGame, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	game, exists := model.GlobalPersistence.Games[gameID]
+	if !exists {
+		return model.UserGame{}, ErrGameNotFound
+	}
+
+	return ds.recordMoveEvent(gameID, game, model.GameEvent{
+		Type:      model.EventMoveDeleted,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Index:     index,
+	})
+}
+
+// Undo deletes the last move of the game.
+func (ds *DataService) Undo(gameID string) (model.UserGame, error) {
+	game, err := ds.GetGame(gameID)
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	if len(game.PlayedMoves) == 0 {
+		return model.UserGame{}, fmt.Errorf("no move to undo")
+	}
+	return ds.DeleteMove(gameID, len(game.PlayedMoves)-1)
+}
+
+// EditMove replaces a played move. The move is scored on the board as it was
+// before the move, the moves after it have to place their tiles on the same
+// squares as before and are scored again.
+func (ds *DataService) EditMove(gameID string, index int, playedMove model.PlayedMove) (model.UserGame, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	game, exists := model.GlobalPersistence.Games[gameID]
+	if !exists {
+		return model.UserGame{}, ErrGameNotFound
+	}
+	if index < 0 || index >= len(game.PlayedMoves) {
+		return model.UserGame{}, fmt.Errorf("move %d not found, the game has %d moves", index, len(game.PlayedMoves))
+	}
+
+	boardBefore, err := logic.RebuildBoard(game.PlayedMoves[:index])
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	playedMove.Timestamp = game.PlayedMoves[index].Timestamp
+	playedMove, err = prepareMove(boardBefore, game.LettersPlaySet, playedMove)
+	if err != nil {
+		return model.UserGame{}, err
+	}
+
+	return ds.recordMoveEvent(gameID, game, model.GameEvent{
+		Type:      model.EventMoveEdited,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Index:     index,
+		Move:      &playedMove,
+	})
+}
+
+// recordMoveEvent stores the game after the event. The caller must hold the
+// GamesLock.
+func (ds *DataService) recordMoveEvent(gameID string, game model.UserGame, event model.GameEvent) (model.UserGame, error) {
+	game, err := logic.RecordEvent(game, event)
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	model.GlobalPersistence.Games[gameID] = game
+
+	if err := ds.Saver.SaveGamesToFile(); err != nil {
+		return model.UserGame{}, fmt.Errorf("failed to save game data: %w", err)
+	}
+	return game, nil
+}
+
+// GameEvents returns the event log of the active game.
+func (ds *DataService) GameEvents(gameID string) ([]model.GameEvent, error) {
+	game, err := ds.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return logic.GameEvents(game), nil
+}
+
+// ReplayEvents rebuilds the active game out of its first count events.
+func (ds *DataService) ReplayEvents(gameID string, count int) (model.UserGame, error) {
+	game, err := ds.GetGame(gameID)
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	return logic.ReplayEvents(game, logic.GameEvents(game), count)
+}
+
+// ReplayMoves returns the active game as it was after its first count moves.
+func (ds *DataService) ReplayMoves(gameID string, count int) (model.GameReplay, error) {
+	game, err := ds.GetGame(gameID)
+	if err != nil {
+		return model.GameReplay{}, err
+	}
+	return logic.ReplayMoves(game, count)
+}
+
+// ReplayEndedGame returns the ended game as it was after its first count
+// moves.
+func (ds *DataService) ReplayEndedGame(gameID string, count int) (model.GameReplay, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	for _, game := range model.GlobalPersistence.EndedGames {
+		if game.ID == gameID {
+			return logic.ReplayMoves(game, count)
+		}
+	}
+	return model.GameReplay{}, fmt.Errorf("ended %w", ErrGameNotFound)
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"buchstaben.go/logic"
 	"buchstaben.go/model"
@@ -14,89 +16,162 @@ import (
 
 type DataService struct {
 	Saver persistence.DataSaver
+
+	indexLock   sync.Mutex
+	wordIndexes map[string]*wordIndexes
 }
 
+// ErrAmbiguousGame is returned for a username with several active games.
+var ErrAmbiguousGame = errors.New("several games are active against the user")
+
+// ErrGameNotFound is returned for a game id without a game.
+var ErrGameNotFound = errors.New("game not found")
+
 func (ds *DataService) ListGames() []model.ListGame {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
 	listGames := []model.ListGame{}
-	for user, game := range model.GlobalPersistence.Games {
+	for gameID, game := range model.GlobalPersistence.Games {
 		listGames = append(listGames, model.ListGame{
-			User:               user,
+			ID:                 gameID,
+			User:               game.User,
 			LastMoveTimestamp:  game.LastMoveTimestamp,
 			GameStartTimestamp: game.GameStartTimestamp,
 			RemindingLetters:   logic.GetRemindingsLetterCount(game.LettersPlaySet),
+			MyScore:            game.MyScore,
+			OpponentScore:      game.OpponentScore,
 		})
 	}
 	return listGames
 }
 
-func (ds *DataService) CreateGame(username string) error {
+// GameID returns the id of the only active game against the user.
+func (ds *DataService) GameID(username string) (string, error) {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	if _, exists := model.GlobalPersistence.Games[username]; exists {
-		return fmt.Errorf("game already exists for this username")
+	return gameIDOf(username)
+}
+
+// gameIDOf finds the active game against the user. The caller must hold the
+// GamesLock.
+func gameIDOf(username string) (string, error) {
+	gameIDs := []string{}
+	for gameID, game := range model.GlobalPersistence.Games {
+		if game.User == username {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	switch len(gameIDs) {
+	case 0:
+		return "", fmt.Errorf("game not found for username")
+	case 1:
+		return gameIDs[0], nil
 	}
+	sort.Strings(gameIDs)
+	return "", fmt.Errorf("%w, use one of the game ids %s", ErrAmbiguousGame, strings.Join(gameIDs, ", "))
+}
 
-	model.GlobalPersistence.Games[username] = model.UserGame{
-		User:               username,
-		LettersPlaySet:     logic.LoadLettersPlaySet(),
-		LastMoveTimestamp:  time.Now().Format("2006-01-02 15:04:05"),
-		GameStartTimestamp: time.Now().Format("2006-01-02 15:04:05"),
-		LetterOverAllValue: 0,
-		PlayedMoves:        []model.PlayedMove{},
+// CreateGame starts a new game against the user, also if other games
+// against the user are still active.
+func (ds *DataService) CreateGame(username, language string) (model.UserGame, error) {
+	if err := checkDictionary(language); err != nil {
+		return model.UserGame{}, err
 	}
-	return ds.Saver.SaveGamesToFile()
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	game, err := logic.RecordEvent(model.UserGame{ID: logic.NewGameID(), User: username}, model.GameEvent{
+		Type:      model.EventCreated,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Language:  logic.LanguageOrDefault(language),
+	})
+	if err != nil {
+		return model.UserGame{}, err
+	}
+
+	model.GlobalPersistence.Games[game.ID] = game
+	return game, ds.Saver.SaveGamesToFile()
 }
 
-func (ds *DataService) DeleteGame(username string) error {
+func (ds *DataService) DeleteGame(gameID string) error {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	if _, exists := model.GlobalPersistence.Games[username]; !exists {
-		return fmt.Errorf("game not found for username")
+	if _, exists := model.GlobalPersistence.Games[gameID]; !exists {
+		return ErrGameNotFound
 	}
 
-	delete(model.GlobalPersistence.Games, username)
+	delete(model.GlobalPersistence.Games, gameID)
 	return ds.Saver.SaveGamesToFile()
 }
 
-func (ds *DataService) EndGame(username string) error {
+// EndGame moves the game to the ended games and records its result. The
+// rack holds the tiles left on my rack, the stored rack is used without it
+// unless I played out. Without any rack the result leaves the tiles on the
+// racks out.
+func (ds *DataService) EndGame(gameID, rack string, playedOut bool) (model.UserGame, error) {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	game, exists := model.GlobalPersistence.Games[username]
+	game, exists := model.GlobalPersistence.Games[gameID]
 	if !exists {
-		return fmt.Errorf("game not found for username")
+		return model.UserGame{}, ErrGameNotFound
+	}
+	if playedOut && rack != "" {
+		return model.UserGame{}, fmt.Errorf("rack has to be empty if I played out")
+	}
+	if rack == "" && !playedOut {
+		rack = game.Rack
+	}
+	rack = strings.ToLower(rack)
+	if len([]rune(rack)) > logic.RackSize {
+		return model.UserGame{}, fmt.Errorf("rack can hold at most %d letters", logic.RackSize)
 	}
 
-	// Set the GameEndTimestamp
-	game.GameEndTimestamp = time.Now().Format("2006-01-02 15:04:05")
+	game, err := logic.RecordEvent(game, model.GameEvent{
+		Type:      model.EventEnded,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Rack:      rack,
+		PlayedOut: playedOut,
+	})
+	if err != nil {
+		return model.UserGame{}, err
+	}
 
 	// Move the game to EndedGames and remove it from active games
 	model.GlobalPersistence.EndedGames = append(model.GlobalPersistence.EndedGames, game)
-	delete(model.GlobalPersistence.Games, username)
+	delete(model.GlobalPersistence.Games, gameID)
 
-	return ds.Saver.SaveGamesToFile()
+	return game, ds.Saver.SaveGamesToFile()
 }
+
+// GetLetters returns the active game against the user, a game is created if
+// there is none.
 func (ds *DataService) GetLetters(username string) (model.UserGame, error) {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	userGame, exists := model.GlobalPersistence.Games[username]
+	gameID, err := gameIDOf(username)
+	if errors.Is(err, ErrAmbiguousGame) {
+		return model.UserGame{}, err
+	}
+	userGame, exists := model.GlobalPersistence.Games[gameID]
 	if !exists {
 		// Create a new game if it doesn't exist
-		userGame = model.UserGame{
-			User:               username,
-			LettersPlaySet:     logic.LoadLettersPlaySet(),
-			LastMoveTimestamp:  time.Now().Format("2006-01-02 15:04:05"),
-			GameStartTimestamp: time.Now().Format("2006-01-02 15:04:05"),
-			LetterOverAllValue: logic.GetLetterValue(logic.LoadLettersPlaySet()),
-			PlayedMoves:        []model.PlayedMove{},
+		if err := checkDictionary(logic.DefaultLanguage); err != nil {
+			return model.UserGame{}, err
+		}
+		userGame, err = logic.RecordEvent(model.UserGame{ID: logic.NewGameID(), User: username}, model.GameEvent{
+			Type:      model.EventCreated,
+			Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+			Language:  logic.DefaultLanguage,
+		})
+		if err != nil {
+			return model.UserGame{}, err
 		}
-		model.GlobalPersistence.Games[username] = userGame
+		model.GlobalPersistence.Games[userGame.ID] = userGame
 
 		// Save the new game to file
 		if err := ds.Saver.SaveGamesToFile(); err != nil {
@@ -107,30 +182,77 @@ func (ds *DataService) GetLetters(username string) (model.UserGame, error) {
 	return userGame, nil
 }
 
-func (ds *DataService) PlayMove(username string, playedMove model.PlayedMove) (model.UserGame, error) {
+// GetGame returns the active game without creating one.
+func (ds *DataService) GetGame(gameID string) (model.UserGame, error) {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
-	game, exists := model.GlobalPersistence.Games[username]
+	game, exists := model.GlobalPersistence.Games[gameID]
 	if !exists {
-		return model.UserGame{}, fmt.Errorf("game not found for username")
+		return model.UserGame{}, ErrGameNotFound
 	}
+	return game, nil
+}
 
-	playedMove.Timestamp = time.Now().Format("2006-01-02 15:04:05")
-	newLettersPlaySet, err := logic.RemoveLetters(game.LettersPlaySet, playedMove.Letters)
+// SetRack stores the tiles on my rack. Only tiles which are not played yet
+// can be on the rack.
+func (ds *DataService) SetRack(gameID, rack string) (model.UserGame, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	game, exists := model.GlobalPersistence.Games[gameID]
+	if !exists {
+		return model.UserGame{}, ErrGameNotFound
+	}
+	rack = strings.ToLower(rack)
+	if len([]rune(rack)) > logic.RackSize {
+		return model.UserGame{}, fmt.Errorf("rack can hold at most %d letters", logic.RackSize)
+	}
+	if _, err := logic.UnseenTiles(game.LettersPlaySet, rack); err != nil {
+		return model.UserGame{}, err
+	}
+
+	game, err := logic.RecordEvent(game, model.GameEvent{
+		Type:      model.EventRackSet,
+		Timestamp: time.Now().Format("2006-01-02 15:04:05"),
+		Rack:      rack,
+	})
 	if err != nil {
 		return model.UserGame{}, err
 	}
+	model.GlobalPersistence.Games[gameID] = game
+	if err := ds.Saver.SaveGamesToFile(); err != nil {
+		return model.UserGame{}, fmt.Errorf("failed to save game data: %w", err)
+	}
+	return game, nil
+}
+
+// PlayMove records the move. Moves with a placement are scored on the board
+// and points differing from the score are refused, moves without one are
+// stored with the points and words as sent, which are not verified.
+func (ds *DataService) PlayMove(gameID string, playedMove model.PlayedMove) (model.UserGame, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	game, exists := model.GlobalPersistence.Games[gameID]
+	if !exists {
+		return model.UserGame{}, ErrGameNotFound
+	}
 
-	updatedGame := model.UserGame{
-		User:               username,
-		LettersPlaySet:     newLettersPlaySet,
-		LastMoveTimestamp:  time.Now().Format("2006-01-02 15:04:05"),
-		GameStartTimestamp: game.GameStartTimestamp,
-		LetterOverAllValue: logic.GetLetterValue(newLettersPlaySet),
-		PlayedMoves:        append(game.PlayedMoves, playedMove),
+	playedMove.Timestamp = time.Now().Format("2006-01-02 15:04:05")
+	playedMove, err := prepareMove(game.Board, game.LettersPlaySet, playedMove)
+	if err != nil {
+		return model.UserGame{}, err
 	}
-	model.GlobalPersistence.Games[username] = updatedGame
+	updatedGame, err := logic.RecordEvent(game, model.GameEvent{
+		Type:      model.EventMovePlayed,
+		Timestamp: playedMove.Timestamp,
+		Move:      &playedMove,
+	})
+	if err != nil {
+		return model.UserGame{}, err
+	}
+	model.GlobalPersistence.Games[gameID] = updatedGame
 
 	if err := ds.Saver.SaveGamesToFile(); err != nil {
 		return model.UserGame{}, fmt.Errorf("failed to save game data: %w", err)
@@ -138,6 +260,157 @@ func (ds *DataService) PlayMove(username string, playedMove model.PlayedMove) (m
 	return updatedGame, nil
 }
 
+// prepareMove scores a move with a placement on the board and fills in its
+// points and words. A move without a placement cannot be scored and is
+// returned as it is.
+func prepareMove(board model.Board, lettersPlaySet model.LettersPlaySet, playedMove model.PlayedMove) (model.PlayedMove, error) {
+	if playedMove.Placement == nil {
+		return playedMove, nil
+	}
+	moveScore, err := logic.ScoreMove(board, *playedMove.Placement, playedMove.Letters,
+		logic.LoadStandardLayout(), logic.LetterValues(lettersPlaySet))
+	if err != nil {
+		return playedMove, err
+	}
+	// Points entered by the user are only kept as a cross-check
+	if playedMove.Points != 0 && playedMove.Points != moveScore.Points {
+		return playedMove, fmt.Errorf("move scores %d points, but %d points were entered, \"Play Move\" ignored", moveScore.Points, playedMove.Points)
+	}
+	playedMove.Points = moveScore.Points
+	if len(playedMove.Words) == 0 {
+		playedMove.Words = moveScore.WordList()
+	}
+	return playedMove, nil
+}
+
+// ownRack returns my rack for the game, the stored rack if rack is empty.
+// The unseen tiles are only the bag and the opponent's rack once my rack is
+// known, so a missing rack is an error.
+func ownRack(game model.UserGame, rack string) (string, error) {
+	if rack == "" {
+		rack = game.Rack
+	}
+	if rack == "" {
+		return "", fmt.Errorf("rack is required, no rack is stored for the game")
+	}
+	if len([]rune(rack)) > logic.RackSize {
+		return "", fmt.Errorf("rack can hold at most %d letters", logic.RackSize)
+	}
+	return rack, nil
+}
+
+// BestMoves returns the highest scoring moves of the rack on the current
+// board of the game, the stored rack of the game is used if rack is empty.
+func (ds *DataService) BestMoves(gameID, rack string, limit int) ([]logic.Move, error) {
+	model.GamesLock.Lock()
+	game, exists := model.GlobalPersistence.Games[gameID]
+	model.GamesLock.Unlock()
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+	rack, err := ownRack(game, rack)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := ds.indexes(game.Language)
+	if err != nil {
+		return nil, err
+	}
+	moves := logic.GenerateMoves(indexes.gaddag, game.Board, rack,
+		logic.LoadStandardLayout(), logic.LetterValues(game.LettersPlaySet))
+	if limit > 0 && len(moves) > limit {
+		moves = moves[:limit]
+	}
+	return moves, nil
+}
+
+// DrawProbabilities calculates the odds of the next draws out of the tiles
+// neither played nor on the rack, the stored rack of the game is used if
+// rack is empty. Without any rack my tiles would count as unseen, so it is
+// required.
+func (ds *DataService) DrawProbabilities(gameID, rack string, draws int) (logic.DrawOdds, error) {
+	model.GamesLock.Lock()
+	game, exists := model.GlobalPersistence.Games[gameID]
+	model.GamesLock.Unlock()
+	if !exists {
+		return logic.DrawOdds{}, ErrGameNotFound
+	}
+	rack, err := ownRack(game, rack)
+	if err != nil {
+		return logic.DrawOdds{}, err
+	}
+
+	unseen, err := logic.UnseenTiles(game.LettersPlaySet, rack)
+	if err != nil {
+		return logic.DrawOdds{}, err
+	}
+	return logic.DrawProbabilities(unseen, draws), nil
+}
+
+// OpponentRack infers the opponent's rack from the tiles neither played nor
+// on my rack, the stored rack of the game is used if rack is empty. Without
+// any rack my tiles would count as the opponent's, so it is required.
+func (ds *DataService) OpponentRack(gameID, rack string) (logic.OpponentRack, error) {
+	model.GamesLock.Lock()
+	game, exists := model.GlobalPersistence.Games[gameID]
+	model.GamesLock.Unlock()
+	if !exists {
+		return logic.OpponentRack{}, ErrGameNotFound
+	}
+	rack, err := ownRack(game, rack)
+	if err != nil {
+		return logic.OpponentRack{}, err
+	}
+
+	unseen, err := logic.UnseenTiles(game.LettersPlaySet, rack)
+	if err != nil {
+		return logic.OpponentRack{}, err
+	}
+	return logic.InferOpponentRack(unseen), nil
+}
+
+// Endgame solves the game once the bag is empty and the opponent's rack is
+// known, the stored rack of the game is used if rack is empty.
+func (ds *DataService) Endgame(gameID, rack string, budget time.Duration) (logic.EndgameSolution, error) {
+	model.GamesLock.Lock()
+	game, exists := model.GlobalPersistence.Games[gameID]
+	model.GamesLock.Unlock()
+	if !exists {
+		return logic.EndgameSolution{}, ErrGameNotFound
+	}
+	rack, err := ownRack(game, rack)
+	if err != nil {
+		return logic.EndgameSolution{}, err
+	}
+	unseen, err := logic.UnseenTiles(game.LettersPlaySet, rack)
+	if err != nil {
+		return logic.EndgameSolution{}, err
+	}
+	opponent := logic.InferOpponentRack(unseen)
+	if !opponent.Exact {
+		return logic.EndgameSolution{}, fmt.Errorf("the bag is not empty yet, %d tiles are unseen", opponent.Unseen)
+	}
+
+	indexes, err := ds.indexes(game.Language)
+	if err != nil {
+		return logic.EndgameSolution{}, err
+	}
+	position := logic.EndgamePosition{
+		Board: game.Board,
+		Racks: [2]string{strings.ToLower(rack), opponent.Rack},
+	}
+	for _, move := range game.PlayedMoves {
+		if move.PlayedByMyself {
+			position.Scores[0] += int(move.Points)
+		} else {
+			position.Scores[1] += int(move.Points)
+		}
+	}
+	return logic.SolveEndgame(indexes.gaddag, position, logic.LoadStandardLayout(),
+		logic.LetterValues(game.LettersPlaySet), budget)
+}
+
 func (ds *DataService) ListEndedGames() []model.ListEndedGame {
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
@@ -145,15 +418,31 @@ func (ds *DataService) ListEndedGames() []model.ListEndedGame {
 	listEndedGames := []model.ListEndedGame{}
 	for _, endedGame := range model.GlobalPersistence.EndedGames {
 		listEndedGames = append(listEndedGames, model.ListEndedGame{
+			ID:                 endedGame.ID,
 			User:               endedGame.User,
 			LastMoveTimestamp:  endedGame.LastMoveTimestamp,
 			GameStartTimestamp: endedGame.GameStartTimestamp,
+			GameEndTimestamp:   endedGame.GameEndTimestamp,
+			Result:             endedGame.Result,
 		})
 	}
 	return listEndedGames
 }
 
-func (ds *DataService) GetPlayedWords(letters string) []model.WordCount {
+// GetPlayedWords returns the words played in the games of the language which
+// can be built out of the letters, followed by the custom words and the words
+// of the word list.
+func (ds *DataService) GetPlayedWords(letters, language string) ([]model.WordCount, error) {
+	language = logic.LanguageOrDefault(language)
+	lettersPlaySet, err := logic.LoadLettersPlaySetFor(language)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := ds.indexes(language)
+	if err != nil {
+		return nil, err
+	}
+
 	model.GamesLock.Lock()
 	defer model.GamesLock.Unlock()
 
@@ -161,92 +450,172 @@ func (ds *DataService) GetPlayedWords(letters string) []model.WordCount {
 
 	// Count words in active games
 	for _, game := range model.GlobalPersistence.Games {
-		countWords(game.PlayedMoves, unfilteredWordCounts)
+		if logic.LanguageOrDefault(game.Language) == language {
+			countWords(game.PlayedMoves, unfilteredWordCounts)
+		}
 	}
 
 	// Count words in ended games
 	for _, endedGame := range model.GlobalPersistence.EndedGames {
-		countWords(endedGame.PlayedMoves, unfilteredWordCounts)
-	}
-
-	// add words from DWDS Word list
-	for word := range model.GlobalWordMap {
-		if len(word) > 15 || len(word) < 2 {
-			continue
-		}
-		// if word contains alpha characters and hyphen, keep it, otherwise skip
-		if !regexp.MustCompile(`^[a-zA-Z-]+$`).MatchString(word) {
-			continue
-		}
-		if _, exists := unfilteredWordCounts[word]; !exists {
-			unfilteredWordCounts[word] = 0
+		if logic.LanguageOrDefault(endedGame.Language) == language {
+			countWords(endedGame.PlayedMoves, unfilteredWordCounts)
 		}
 	}
 
 	// convert map to slice of WordCount
 	wordsCount := make([]model.WordCount, 0, len(unfilteredWordCounts))
-	for word := range unfilteredWordCounts {
-		if !buildWordOutOfLetters(word, letters) {
+	seen := make(map[string]bool)
+	values := logic.LetterValues(lettersPlaySet)
+	letters = logic.NormalizeWord(letters)
+	for word, count := range unfilteredWordCounts {
+		blankLetters, ok := matchWordToLetters(word, letters)
+		if !ok {
 			continue
 		}
+		seen[word] = true
 		wordsCount = append(wordsCount, model.WordCount{
 			Word:         word,
-			CurrentCount: 0,
+			CurrentCount: count,
+			Points:       wordPoints(word, blankLetters, values),
+			BlankLetters: blankLetters,
+			Source:       model.SourcePlayed,
 		})
 	}
-	// Sort the wordsCount slice by the length of the words in descending order,
-	// and for words with the same length, sort them alphabetically in ascending order.
-	sort.Slice(wordsCount, func(i, j int) bool {
-		if len(wordsCount[i].Word) == len(wordsCount[j].Word) {
-			return wordsCount[i].Word < wordsCount[j].Word // Sort alphabetically if lengths are equal
-		}
-		return len(wordsCount[i].Word) > len(wordsCount[j].Word) // Sort by length in descending order
-	})
-	if len(wordsCount) > 100 {
-		wordsCount = wordsCount[:100]
+
+	// words marked as invalid are not added from the word lists
+	for word := range invalidWords(language) {
+		seen[word] = true
 	}
 
-	return wordsCount
+	// add custom words
+	customWords := customWordIndex(language, "", logic.Alphabet(lettersPlaySet))
+	wordsCount = appendMatches(wordsCount, seen, customWords.Match(letters), model.SourceCustom, values)
+
+	// add words from the word list
+	wordsCount = appendMatches(wordsCount, seen, indexes.words.Match(letters), model.SourceDWDS, values)
+
+	return sortWordCounts(wordsCount), nil
 }
 
-func (ds *DataService) FindWords(letters string) []model.WordCount {
+// FindWords returns the words of the word list and the custom words which
+// can be built out of the letters with the tiles of the language. Custom
+// words can be limited to a category, words marked as invalid are left out.
+func (ds *DataService) FindWords(letters, language, category string) ([]model.WordCount, error) {
+	lettersPlaySet, err := logic.LoadLettersPlaySetFor(language)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := ds.indexes(language)
+	if err != nil {
+		return nil, err
+	}
+
 	model.GamesLock.Lock()
-	defer model.GamesLock.Unlock()
+	customWords := customWordIndex(language, category, logic.Alphabet(lettersPlaySet))
+	// words marked as invalid are skipped like words already found
+	seen := invalidWords(language)
+	model.GamesLock.Unlock()
 
-	unfilteredWordCounts := make(map[string]int)
+	values := logic.LetterValues(lettersPlaySet)
+	wordsCount := appendMatches([]model.WordCount{}, seen, customWords.Match(letters), model.SourceCustom, values)
+	wordsCount = appendMatches(wordsCount, seen, indexes.words.Match(letters), model.SourceDWDS, values)
 
-	// add words from DWDS Word list
-	for word := range model.GlobalWordMap {
-		if len(word) > 15 || len(word) < 2 {
-			continue
-		}
-		// if word contains alpha characters and hyphen, keep it, otherwise skip
-		if !regexp.MustCompile(`^[a-zA-Z-]+$`).MatchString(word) {
-			continue
-		}
-		if _, exists := unfilteredWordCounts[word]; !exists {
-			unfilteredWordCounts[word] = 0
-		}
-	}
+	return sortWordCounts(wordsCount), nil
+}
 
-	// convert map to slice of WordCount
-	wordsCount := make([]model.WordCount, 0, len(unfilteredWordCounts))
-	for word := range unfilteredWordCounts {
-		if !buildWordOutOfLetters(word, letters) {
+// appendMatches adds the matches not seen yet to the words.
+func appendMatches(wordsCount []model.WordCount, seen map[string]bool, matches []logic.WordMatch, source string, values map[string]uint) []model.WordCount {
+	for _, match := range matches {
+		if seen[match.Word] {
 			continue
 		}
+		seen[match.Word] = true
 		wordsCount = append(wordsCount, model.WordCount{
-			Word:         word,
+			Word:         match.Word,
 			CurrentCount: 0,
+			Points:       wordPoints(match.Word, match.BlankLetters, values),
+			BlankLetters: match.BlankLetters,
+			Source:       source,
 		})
 	}
-	// Sort the wordsCount slice by the length of the words in descending order,
-	// and for words with the same length, sort them alphabetically in ascending order.
+	return wordsCount
+}
+
+// Pattern anchors
+const (
+	AnchorBoth  = "both"
+	AnchorStart = "start"
+	AnchorEnd   = "end"
+	AnchorNone  = "none"
+)
+
+// FindPatternWords returns the words of the word list which match the
+// pattern and can be completed with the letters.
+func (ds *DataService) FindPatternWords(query model.PatternQuery) ([]model.WordCount, error) {
+	lettersPlaySet, err := logic.LoadLettersPlaySetFor(query.Language)
+	if err != nil {
+		return nil, err
+	}
+	pattern := []rune(logic.NormalizeWord(query.Pattern))
+	if len(pattern) == 0 || len(pattern) > logic.BoardSize {
+		return nil, fmt.Errorf("pattern must have between 1 and %d squares", logic.BoardSize)
+	}
+	alphabet := logic.AlphabetSet(logic.Alphabet(lettersPlaySet))
+	for _, square := range pattern {
+		if square != logic.OpenSquare && !alphabet[square] {
+			return nil, fmt.Errorf("pattern letter %q is not valid", string(square))
+		}
+	}
+	if query.MaxLength != 0 && query.MaxLength < query.MinLength {
+		return nil, fmt.Errorf("max length must not be smaller than min length")
+	}
+
+	var anchorStart, anchorEnd bool
+	switch query.Anchor {
+	case "", AnchorBoth:
+		anchorStart, anchorEnd = true, true
+	case AnchorStart:
+		anchorStart = true
+	case AnchorEnd:
+		anchorEnd = true
+	case AnchorNone:
+	default:
+		return nil, fmt.Errorf("anchor %q is not valid, use %q, %q, %q or %q", query.Anchor, AnchorBoth, AnchorStart, AnchorEnd, AnchorNone)
+	}
+
+	indexes, err := ds.indexes(query.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	model.GamesLock.Lock()
+	customWords := customWordIndex(query.Language, query.Category, logic.Alphabet(lettersPlaySet))
+	seen := invalidWords(query.Language)
+	model.GamesLock.Unlock()
+
+	values := logic.LetterValues(lettersPlaySet)
+	wordsCount := appendMatches([]model.WordCount{}, seen,
+		customWords.MatchPattern(query.Pattern, query.Letters, anchorStart, anchorEnd, query.MinLength, query.MaxLength),
+		model.SourceCustom, values)
+	wordsCount = appendMatches(wordsCount, seen,
+		indexes.words.MatchPattern(query.Pattern, query.Letters, anchorStart, anchorEnd, query.MinLength, query.MaxLength),
+		model.SourceDWDS, values)
+
+	return sortWordCounts(wordsCount), nil
+}
+
+// sortWordCounts sorts the words by the length of the words in descending
+// order, and for words with the same length, alphabetically in ascending
+// order. Only the first 100 words are kept.
+func sortWordCounts(wordsCount []model.WordCount) []model.WordCount {
 	sort.Slice(wordsCount, func(i, j int) bool {
-		if len(wordsCount[i].Word) == len(wordsCount[j].Word) {
+		lengthI := utf8.RuneCountInString(wordsCount[i].Word)
+		lengthJ := utf8.RuneCountInString(wordsCount[j].Word)
+		if lengthI == lengthJ {
 			return wordsCount[i].Word < wordsCount[j].Word // Sort alphabetically if lengths are equal
 		}
-		return len(wordsCount[i].Word) > len(wordsCount[j].Word) // Sort by length in descending order
+		return lengthI > lengthJ // Sort by length in descending order
 	})
 	if len(wordsCount) > 100 {
 		wordsCount = wordsCount[:100]
@@ -257,29 +626,69 @@ func (ds *DataService) FindWords(letters string) []model.WordCount {
 
 // check if the word can be built out of the letters
 func buildWordOutOfLetters(word, letters string) bool {
+	_, ok := matchWordToLetters(word, letters)
+	return ok
+}
+
+// matchWordToLetters checks if the word can be built out of the letters,
+// every "*" in the letters is a blank standing for any letter. The letters
+// the blanks were used for are returned in the order of the word.
+func matchWordToLetters(word, letters string) ([]string, bool) {
 	// Create a map to count occurrences of each character in the letters string
 	letterCounts := make(map[rune]int)
 	for _, char := range letters {
 		letterCounts[char]++
 	}
 
-	// Check if each character in the word can be formed using the letters
+	// Letters on the rack are used first, blanks only fill the gaps
+	missing := make(map[rune]int)
 	for _, char := range word {
 		if letterCounts[char] > 0 {
 			letterCounts[char]-- // Decrement the count for the character
 		} else {
-			return false // Character not found or insufficient occurrences
+			missing[char]++
+		}
+	}
+
+	var blankLetters []string
+	for _, char := range word {
+		if missing[char] == 0 {
+			continue
+		}
+		if letterCounts['*'] == 0 {
+			return nil, false // Character not found or insufficient occurrences
 		}
+		letterCounts['*']--
+		missing[char]--
+		blankLetters = append(blankLetters, string(char))
 	}
 
-	return true
+	return blankLetters, true
+}
+
+// wordPoints sums the letter values of the word, letters played with a
+// blank score zero.
+func wordPoints(word string, blankLetters []string, values map[string]uint) uint {
+	blanks := make(map[string]int)
+	for _, letter := range blankLetters {
+		blanks[letter]++
+	}
+	points := uint(0)
+	for _, char := range word {
+		if blanks[string(char)] > 0 {
+			blanks[string(char)]--
+			continue
+		}
+		points += values[string(char)]
+	}
+	return points
 }
 
 func countWords(playedMoves []model.PlayedMove, wordCounts map[string]int) {
 	for _, move := range playedMoves {
 		if move.Words != nil {
 			for _, word := range move.Words {
-				word = strings.ToLower(word)
+				word = logic.NormalizeWord(word)
 				wordCounts[word]++
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"buchstaben.go/logic"
 	"buchstaben.go/model"
@@ -42,6 +43,9 @@ func setupTestEnvironment() (*DataService, *MockDataSaver) {
 		EndedGames: []model.UserGame{},
 	}
 
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {}, "en": {}, "nl": {}}
+	model.GlobalMissingDictionaries = nil
+
 	mock := &MockDataSaver{}
 	service := &DataService{
 		Saver: mock,
@@ -84,7 +88,7 @@ func TestCreateGame(t *testing.T) {
 	service, mock := setupTestEnvironment()
 
 	// Test successful creation
-	err := service.CreateGame("testuser")
+	created, err := service.CreateGame("testuser", "")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -93,7 +97,7 @@ func TestCreateGame(t *testing.T) {
 		t.Error("Expected SaveGamesToFile to be called")
 	}
 
-	game, exists := model.GlobalPersistence.Games["testuser"]
+	game, exists := model.GlobalPersistence.Games[created.ID]
 	if !exists {
 		t.Error("Game was not created")
 		return
@@ -103,26 +107,53 @@ func TestCreateGame(t *testing.T) {
 		t.Errorf("Expected username 'testuser', got '%s'", game.User)
 	}
 
-	// Test duplicate user creation
+	// Test a second game against the same user
 	mock.GameSaveCalled = false // Reset flag
-	err = service.CreateGame("testuser")
+	second, err := service.CreateGame("testuser", "")
 
-	if err == nil {
-		t.Error("Expected error for duplicate user, got nil")
+	if err != nil {
+		t.Errorf("Expected no error for a second game, got %v", err)
 	}
-	if mock.GameSaveCalled {
-		t.Error("SaveGamesToFile should not be called for duplicate user")
+	if second.ID == created.ID {
+		t.Error("Expected the second game to get its own id")
+	}
+	if len(model.GlobalPersistence.Games) != 2 {
+		t.Errorf("Expected 2 active games, got %d", len(model.GlobalPersistence.Games))
 	}
 
 	// Test error during save
 	mock.GameSaveError = fmt.Errorf("save error")
-	err = service.CreateGame("newuser")
+	_, err = service.CreateGame("newuser", "")
 
 	if err == nil || err.Error() != "save error" {
 		t.Errorf("Expected 'save error', got %v", err)
 	}
 }
 
+func TestGameID(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.GameID("testuser")
+	assert.EqualError(t, err, "game not found for username")
+
+	first, err := service.CreateGame("testuser", "")
+	assert.NoError(t, err)
+	gameID, err := service.GameID("testuser")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, gameID)
+
+	_, err = service.CreateGame("testuser", "")
+	assert.NoError(t, err)
+	_, err = service.GameID("testuser")
+	assert.ErrorIs(t, err, ErrAmbiguousGame, "Expected an error for several games against the user")
+	_, err = service.GetLetters("testuser")
+	assert.ErrorIs(t, err, ErrAmbiguousGame)
+
+	game, err := service.GetGame(first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "testuser", game.User)
+}
+
 func TestDeleteGame(t *testing.T) {
 	service, mock := setupTestEnvironment()
 
@@ -161,7 +192,7 @@ func TestEndGame(t *testing.T) {
 	service, mock := setupTestEnvironment()
 
 	// Test ending non-existent game
-	err := service.EndGame("nonexistent")
+	_, err := service.EndGame("nonexistent", "", false)
 
 	if err == nil {
 		t.Error("Expected error for non-existent game, got nil")
@@ -176,7 +207,7 @@ func TestEndGame(t *testing.T) {
 	}
 
 	// Test successful end
-	err = service.EndGame("testuser")
+	_, err = service.EndGame("testuser", "", false)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -203,6 +234,54 @@ func TestEndGame(t *testing.T) {
 	}
 }
 
+func TestEndGameResult(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User: "testuser",
+		LettersPlaySet: model.LettersPlaySet{
+			{Letter: "a", OriginalCount: 5, CurrentCount: 2, Value: 1},
+			{Letter: "q", OriginalCount: 1, CurrentCount: 1, Value: 10},
+		},
+		PlayedMoves: []model.PlayedMove{
+			{Letters: "hut", Points: 30, PlayedByMyself: true},
+			{Letters: "ab", Points: 20},
+		},
+		Rack: "aaq",
+	}
+
+	_, err := service.EndGame("testuser", "aaqaaqaa", false)
+	assert.Error(t, err, "Expected error for a rack with too many letters")
+
+	game, err := service.EndGame("testuser", "AQ", false)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.GameResult{MyScore: 19, OpponentScore: 19, Spread: 0, Outcome: model.OutcomeDraw, MyRackAdjustment: -11, OpponentRackAdjustment: -1}, game.Result)
+
+	ended := model.GlobalPersistence.EndedGames[0]
+	assert.Equal(t, game.Result, ended.Result)
+	assert.Equal(t, "aq", ended.Rack)
+
+	listed := service.ListEndedGames()
+	assert.Equal(t, game.Result, listed[0].Result)
+
+	unfinished := model.UserGame{
+		User:           "other",
+		LettersPlaySet: model.LettersPlaySet{{Letter: "a", OriginalCount: 5, CurrentCount: 2, Value: 1}},
+		PlayedMoves:    []model.PlayedMove{{Letters: "hut", Points: 30, PlayedByMyself: true}},
+	}
+	model.GlobalPersistence.Games["other"] = unfinished
+	_, err = service.EndGame("other", "a", true)
+	assert.Error(t, err, "Expected error for a rack if I played out")
+	game, err = service.EndGame("other", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, game.Result.MyRackAdjustment, "Expected the opponent's rack to count for me")
+
+	model.GlobalPersistence.Games["other"] = unfinished
+	game, err = service.EndGame("other", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.GameResult{MyScore: 30, Spread: 30, Outcome: model.OutcomeWon}, game.Result, "Expected no rack adjustments without my rack")
+}
+
 func TestGetLetters(t *testing.T) {
 	service, mock := setupTestEnvironment()
 
@@ -221,7 +300,7 @@ func TestGetLetters(t *testing.T) {
 	}
 
 	// Verify game was stored in global state
-	if _, exists := model.GlobalPersistence.Games["newuser"]; !exists {
+	if _, exists := model.GlobalPersistence.Games[game.ID]; !exists {
 		t.Error("Game was not created in global state")
 	}
 
@@ -300,6 +379,26 @@ func TestPlayMove(t *testing.T) {
 	}
 }
 
+func TestOpponentStats(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.OpponentStats("testuser")
+	assert.Error(t, err, "Expected error without games")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{User: "testuser", PlayedMoves: []model.PlayedMove{{Letters: "hut", Points: 8, PlayedByMyself: true}}}
+	model.GlobalPersistence.EndedGames = []model.UserGame{
+		{User: "testuser", PlayedMoves: []model.PlayedMove{{Letters: "hut", Points: 2, PlayedByMyself: true}, {Letters: "ab", Points: 4}}},
+		{User: "other", PlayedMoves: []model.PlayedMove{{Letters: "ob", Points: 6, PlayedByMyself: true}}},
+	}
+
+	stats, err := service.OpponentStats("testuser")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stats.Games, "Expected only games against the user")
+	assert.Equal(t, 1, stats.ActiveGames)
+	assert.Equal(t, 1, stats.Losses)
+	assert.Equal(t, uint(8), stats.HighestMove.Points)
+}
+
 func TestListEndedGames(t *testing.T) {
 	service, _ := setupTestEnvironment()
 
@@ -339,11 +438,12 @@ func TestListEndedGames(t *testing.T) {
 func TestGetPlayedWords(t *testing.T) {
 	service, _ := setupTestEnvironment()
 
-	letters := "el"
+	letters := "hello"
 
 	// Test with no words
 	model.GlobalPersistence.Games["user1"] = model.UserGame{}
-	words := service.GetPlayedWords(letters)
+	words, err := service.GetPlayedWords(letters, "")
+	assert.NoError(t, err)
 
 	if len(words) != 0 {
 		t.Errorf("Expected 0 words, got %d", len(words))
@@ -366,9 +466,9 @@ func TestGetPlayedWords(t *testing.T) {
 		},
 	}
 
-	// Test with words
-	words = service.GetPlayedWords(letters)
-	fmt.Printf("words: %v\n", words)
+	// Test with words, only the words built out of the letters are returned
+	words, err = service.GetPlayedWords(letters, "")
+	assert.NoError(t, err)
 
 	if len(words) != 2 {
 		t.Errorf("Expected 2 unique words, got %d", len(words))
@@ -377,9 +477,7 @@ func TestGetPlayedWords(t *testing.T) {
 	// Create expected map to verify counts
 	expectedCounts := map[string]int{
 		"hello": 2,
-		"world": 1,
-		"test":  1,
-		"case":  1,
+		"hell":  1,
 	}
 
 	// Verify counts
@@ -493,3 +591,822 @@ func TestBuildWordOutOfLetters(t *testing.T) {
 		})
 	}
 }
+
+func TestPlayMoveWithPlacement(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+		PlayedMoves:    []model.PlayedMove{},
+		Board:          model.Board{},
+	}
+
+	move := model.PlayedMove{
+		Letters:        "qi",
+		Words:          []string{"qi"},
+		PlayedByMyself: true,
+		Placement:      &model.Placement{Row: 7, Col: 7, Direction: model.Horizontal, Blanks: []int{0}},
+	}
+
+	updatedGame, err := service.PlayMove("testuser", move)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Board{
+		{Row: 7, Col: 7, Letter: "q", Blank: true},
+		{Row: 7, Col: 8, Letter: "i"},
+	}, updatedGame.Board, "Tiles should be placed on the board")
+	assert.Equal(t, uint(1), updatedGame.PlayedMoves[0].Points, "Points should be computed from the board")
+
+	// The blank is taken from the letter set instead of the 'q'
+	for _, l := range updatedGame.LettersPlaySet {
+		switch l.Letter {
+		case "q":
+			assert.Equal(t, uint(1), l.CurrentCount, "Letter 'q' should not be removed")
+		case "*":
+			assert.Equal(t, uint(1), l.CurrentCount, "One blank should be removed")
+		}
+	}
+
+	// Entered points which do not match the computed score are rejected
+	move.Placement = &model.Placement{Row: 8, Col: 8, Direction: model.Horizontal}
+	move.Letters = "n"
+	move.Words = nil
+	move.Points = 5
+	_, err = service.PlayMove("testuser", move)
+	assert.Error(t, err, "Expected error for mismatching points")
+	assert.Contains(t, err.Error(), "2 points")
+
+	move.Points = 0
+	updatedGame, err = service.PlayMove("testuser", move)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), updatedGame.PlayedMoves[1].Points, "Points should be computed from the board")
+	assert.Equal(t, []string{"in"}, updatedGame.PlayedMoves[1].Words, "Words should be taken from the board")
+
+	// A move which is not connected to the board is rejected
+	move.Placement = &model.Placement{Row: 0, Col: 0, Direction: model.Horizontal}
+	move.Letters = "ab"
+	_, err = service.PlayMove("testuser", move)
+	assert.Error(t, err, "Expected error for unconnected move")
+	assert.Len(t, model.GlobalPersistence.Games["testuser"].Board, 3, "Board should still hold only the 3 tiles of the played moves")
+}
+
+func TestBestMoves(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"hut": "", "huts": "", "us": ""}}
+
+	_, err := service.BestMoves("nonexistent", "s", 10)
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+		Board: model.Board{
+			{Row: 7, Col: 6, Letter: "h"},
+			{Row: 7, Col: 7, Letter: "u"},
+			{Row: 7, Col: 8, Letter: "t"},
+		},
+	}
+
+	_, err = service.BestMoves("testuser", "abcdefgh", 10)
+	assert.Error(t, err, "Expected error for too many letters on the rack")
+
+	moves, err := service.BestMoves("testuser", "s", 1)
+	assert.NoError(t, err)
+	assert.Len(t, moves, 1, "Expected moves to be limited")
+	assert.Equal(t, []string{"huts"}, moves[0].WordList())
+	assert.Equal(t, uint(5), moves[0].Points)
+}
+
+func TestDrawProbabilities(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.DrawProbabilities("nonexistent", "", 7)
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+	}
+
+	_, err = service.DrawProbabilities("testuser", "", 7)
+	assert.EqualError(t, err, "rack is required, no rack is stored for the game", "Expected my tiles not to count as unseen")
+
+	_, err = service.DrawProbabilities("testuser", "abcdefgh", 7)
+	assert.Error(t, err, "Expected error for too many letters on the rack")
+
+	_, err = service.DrawProbabilities("testuser", "qq", 7)
+	assert.Error(t, err, "Expected error for letters not available anymore")
+
+	odds, err := service.DrawProbabilities("testuser", "q*", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, logic.GetRemindingsLetterCount(logic.LoadLettersPlaySet())-2, odds.Unseen)
+	assert.InDelta(t, 1.0/float64(odds.Unseen), odds.Blank, 1e-9, "Expected one blank left")
+	for _, letter := range odds.Letters {
+		if letter.Letter == "q" {
+			assert.Equal(t, 0.0, letter.Probability, "Expected the only q to be on the rack")
+		}
+	}
+}
+
+func TestSetRack(t *testing.T) {
+	service, mock := setupTestEnvironment()
+
+	_, err := service.SetRack("nonexistent", "abc")
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	lettersPlaySet := logic.LoadLettersPlaySet()
+	lettersPlaySet, err = logic.RemoveLetters(lettersPlaySet, "q")
+	assert.NoError(t, err)
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: lettersPlaySet,
+		Board:          model.Board{{Row: 7, Col: 7, Letter: "q"}},
+	}
+
+	_, err = service.SetRack("testuser", "abcdefgh")
+	assert.Error(t, err, "Expected error for too many letters")
+
+	_, err = service.SetRack("testuser", "q")
+	assert.Error(t, err, "Expected error for a tile already played")
+	assert.False(t, mock.GameSaveCalled)
+
+	game, err := service.SetRack("testuser", "UI*")
+	assert.NoError(t, err)
+	assert.Equal(t, "ui*", game.Rack)
+	assert.True(t, mock.GameSaveCalled)
+	assert.Equal(t, "ui*", model.GlobalPersistence.Games["testuser"].Rack)
+
+	_, err = service.BestMoves("testuser", "", 10)
+	assert.NoError(t, err, "Expected the stored rack to be used")
+
+	odds, err := service.DrawProbabilities("testuser", "", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, logic.GetRemindingsLetterCount(lettersPlaySet)-3, odds.Unseen, "Expected the stored rack to be used")
+}
+
+func TestOpponentRack(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.OpponentRack("nonexistent", "")
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	lettersPlaySet := logic.LoadLettersPlaySet()
+	for i := range lettersPlaySet {
+		lettersPlaySet[i].CurrentCount = 0
+		switch lettersPlaySet[i].Letter {
+		case "e":
+			lettersPlaySet[i].CurrentCount = 4
+		case "n", "r":
+			lettersPlaySet[i].CurrentCount = 3
+		}
+	}
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: lettersPlaySet,
+	}
+	_, err = service.OpponentRack("testuser", "")
+	assert.EqualError(t, err, "rack is required, no rack is stored for the game", "Expected my rack not to be taken for the opponent's")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: lettersPlaySet,
+		Rack:           "eerr",
+	}
+
+	opponent, err := service.OpponentRack("testuser", "")
+	assert.NoError(t, err)
+	assert.True(t, opponent.Exact, "Expected the stored rack to be used")
+	assert.Equal(t, "eennnr", opponent.Rack)
+
+	opponent, err = service.OpponentRack("testuser", "e")
+	assert.NoError(t, err)
+	assert.False(t, opponent.Exact)
+	assert.Equal(t, uint(9), opponent.Unseen)
+	assert.Equal(t, uint(2), opponent.InBag)
+
+	_, err = service.OpponentRack("testuser", "q")
+	assert.Error(t, err, "Expected error for letters not available anymore")
+}
+
+func TestPlayMoveUpdatesRack(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+		Rack:           "abcdef*",
+	}
+
+	updatedGame, err := service.PlayMove("testuser", model.PlayedMove{Letters: "bx", Placement: &model.Placement{
+		Row: 7, Col: 7, Direction: model.Horizontal, Blanks: []int{1},
+	}, PlayedByMyself: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "acdef", updatedGame.Rack, "Expected the played tiles to leave my rack")
+
+	updatedGame, err = service.PlayMove("testuser", model.PlayedMove{Letters: "ac"})
+	assert.NoError(t, err)
+	assert.Equal(t, "acdef", updatedGame.Rack, "Expected moves of the opponent to keep my rack")
+}
+
+func TestEndgame(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"hut": "", "huts": ""}}
+
+	_, err := service.Endgame("nonexistent", "s", time.Second)
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+	}
+	_, err = service.Endgame("testuser", "s", time.Second)
+	assert.Error(t, err, "Expected error while the bag is not empty")
+
+	lettersPlaySet := logic.LoadLettersPlaySet()
+	for i := range lettersPlaySet {
+		lettersPlaySet[i].CurrentCount = 0
+		if lettersPlaySet[i].Letter == "s" || lettersPlaySet[i].Letter == "q" {
+			lettersPlaySet[i].CurrentCount = 1
+		}
+	}
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: lettersPlaySet,
+		Board: model.Board{
+			{Row: 7, Col: 6, Letter: "h"},
+			{Row: 7, Col: 7, Letter: "u"},
+			{Row: 7, Col: 8, Letter: "t"},
+		},
+		PlayedMoves: []model.PlayedMove{
+			{Letters: "hut", Points: 4, PlayedByMyself: true},
+			{Letters: "xy", Points: 30},
+		},
+		Rack: "s",
+	}
+
+	_, err = service.Endgame("testuser", "", time.Second)
+	assert.NoError(t, err)
+
+	solution, err := service.Endgame("testuser", "s", time.Second)
+	assert.NoError(t, err)
+	assert.True(t, solution.Exhaustive)
+	assert.Equal(t, [2]int{4 + 5 + 10, 30 - 10}, solution.FinalScores)
+	assert.Equal(t, -1, solution.Spread)
+}
+
+func TestDeleteMove(t *testing.T) {
+	service, mock := setupTestEnvironment()
+
+	_, err := service.DeleteMove("nonexistent", 0)
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+		PlayedMoves:    []model.PlayedMove{},
+		Board:          model.Board{},
+	}
+	_, err = service.PlayMove("testuser", model.PlayedMove{Letters: "hut", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+	_, err = service.PlayMove("testuser", model.PlayedMove{Letters: "s", Placement: &model.Placement{Row: 7, Col: 9, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+
+	_, err = service.DeleteMove("testuser", 2)
+	assert.Error(t, err, "Expected error for move out of range")
+
+	_, err = service.DeleteMove("testuser", 0)
+	assert.Error(t, err, "Expected error if a later move loses its place")
+	assert.Len(t, model.GlobalPersistence.Games["testuser"].PlayedMoves, 2, "Expected the game to be unchanged")
+
+	mock.GameSaveCalled = false
+	game, err := service.DeleteMove("testuser", 1)
+	assert.NoError(t, err)
+	assert.True(t, mock.GameSaveCalled)
+	assert.Len(t, game.PlayedMoves, 1)
+	assert.Len(t, game.Board, 3)
+	played, _ := logic.RemoveLetters(logic.LoadLettersPlaySet(), "hut")
+	assert.EqualValues(t, played, game.LettersPlaySet, "Expected the tiles to be back in the set")
+	assert.Equal(t, logic.GetLetterValue(played), game.LetterOverAllValue)
+}
+
+func TestEditMove(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.EditMove("nonexistent", 0, model.PlayedMove{})
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	model.GlobalPersistence.Games["testuser"] = model.UserGame{
+		User:           "testuser",
+		LettersPlaySet: logic.LoadLettersPlaySet(),
+		PlayedMoves:    []model.PlayedMove{},
+		Board:          model.Board{},
+	}
+	_, err = service.PlayMove("testuser", model.PlayedMove{Letters: "hut", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+	_, err = service.PlayMove("testuser", model.PlayedMove{Letters: "ab"})
+	assert.NoError(t, err)
+
+	game, err := service.EditMove("testuser", 0, model.PlayedMove{Letters: "hat", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal, Blanks: []int{1}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hat"}, game.PlayedMoves[0].Words)
+	assert.Equal(t, "a", game.Board[1].Letter)
+	assert.True(t, game.Board[1].Blank)
+	played, _ := logic.RemoveLetters(logic.LoadLettersPlaySet(), "h*tab")
+	assert.EqualValues(t, played, game.LettersPlaySet, "Expected the tiles of the typo to be back in the set")
+
+	_, err = service.EditMove("testuser", 1, model.PlayedMove{Letters: "1"})
+	assert.Error(t, err, "Expected error for invalid letter")
+	assert.EqualValues(t, played, model.GlobalPersistence.Games["testuser"].LettersPlaySet, "Expected the game to be unchanged")
+
+	_, err = service.EditMove("testuser", 0, model.PlayedMove{Letters: "hut", Points: 99, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.Error(t, err, "Expected error for wrong points")
+
+	// a later move playing through the edited tiles
+	game, err = service.PlayMove("testuser", model.PlayedMove{Letters: "tt", Placement: &model.Placement{Row: 6, Col: 7, Direction: model.Vertical}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), game.PlayedMoves[2].Points, "Expected the blank to score nothing")
+	game, err = service.EditMove("testuser", 0, model.PlayedMove{Letters: "hut", Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tut"}, game.PlayedMoves[2].Words, "Expected the later move to be scored again")
+	assert.Equal(t, uint(3), game.PlayedMoves[2].Points)
+	assert.Equal(t, game.PlayedMoves[0].Points+game.PlayedMoves[2].Points, uint(game.MyScore+game.OpponentScore))
+
+	_, err = service.EditMove("testuser", 0, model.PlayedMove{Letters: "ut", Placement: &model.Placement{Row: 7, Col: 7, Direction: model.Vertical}})
+	assert.Error(t, err, "Expected error if the later move would move to other squares")
+	assert.Equal(t, "hut", model.GlobalPersistence.Games["testuser"].PlayedMoves[0].Letters, "Expected the game to be unchanged")
+}
+
+func TestGameEventLog(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.GameEvents("nonexistent")
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	created, err := service.CreateGame("testuser", "")
+	assert.NoError(t, err)
+	gameID := created.ID
+	_, err = service.SetRack(gameID, "hut")
+	assert.NoError(t, err)
+	_, err = service.PlayMove(gameID, model.PlayedMove{Letters: "hut", PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+	_, err = service.PlayMove(gameID, model.PlayedMove{Letters: "ab"})
+	assert.NoError(t, err)
+
+	game, err := service.Undo(gameID)
+	assert.NoError(t, err)
+	assert.Len(t, game.PlayedMoves, 1, "Expected the last move to be undone")
+
+	events, err := service.GameEvents(gameID)
+	assert.NoError(t, err)
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{model.EventCreated, model.EventRackSet, model.EventMovePlayed, model.EventMovePlayed, model.EventMoveDeleted}, types)
+
+	replayed, err := service.ReplayEvents(gameID, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, game.PlayedMoves, replayed.PlayedMoves, "Expected the log to rebuild the game")
+	assert.Equal(t, game.Board, replayed.Board)
+	assert.EqualValues(t, game.LettersPlaySet, replayed.LettersPlaySet)
+
+	replayed, err = service.ReplayEvents(gameID, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, replayed.PlayedMoves)
+	assert.Equal(t, "hut", replayed.Rack)
+
+	_, err = service.Undo(gameID)
+	assert.NoError(t, err)
+	_, err = service.Undo(gameID)
+	assert.Error(t, err, "Expected error without moves to undo")
+
+	_, err = service.EndGame(gameID, "", false)
+	assert.NoError(t, err)
+	ended := model.GlobalPersistence.EndedGames[len(model.GlobalPersistence.EndedGames)-1]
+	assert.Equal(t, model.EventEnded, ended.Events[len(ended.Events)-1].Type)
+}
+
+func TestReplayMoves(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.ReplayMoves("nonexistent", 1)
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	created, err := service.CreateGame("testuser", "")
+	assert.NoError(t, err)
+	gameID := created.ID
+	_, err = service.PlayMove(gameID, model.PlayedMove{Letters: "hut", Points: 4, PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+	_, err = service.PlayMove(gameID, model.PlayedMove{Letters: "ab", Points: 6})
+	assert.NoError(t, err)
+
+	replay, err := service.ReplayMoves(gameID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, gameID, replay.ID)
+	assert.Equal(t, 2, replay.TotalMoves)
+	assert.Equal(t, 4, replay.MyScore)
+	assert.Zero(t, replay.OpponentScore)
+	assert.Equal(t, "hut", replay.LastMove.Letters)
+
+	_, err = service.ReplayMoves(gameID, 3)
+	assert.Error(t, err, "Expected error for a move after the last one")
+
+	_, err = service.ReplayEndedGame(gameID, 1)
+	assert.EqualError(t, err, "ended game not found")
+
+	_, err = service.EndGame(gameID, "", false)
+	assert.NoError(t, err)
+	replay, err = service.ReplayEndedGame(gameID, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, replay.Move)
+	assert.Equal(t, 6, replay.OpponentScore)
+}
+
+func TestGCGExportImport(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, _, err := service.ExportGCG("nonexistent")
+	assert.Error(t, err, "Expected error for non-existent game")
+
+	created, err := service.CreateGame("testuser", "")
+	assert.NoError(t, err)
+	_, err = service.PlayMove(created.ID, model.PlayedMove{Letters: "hut", PlayedByMyself: true, Placement: &model.Placement{Row: 7, Col: 6, Direction: model.Horizontal}})
+	assert.NoError(t, err)
+
+	game, gcg, err := service.ExportGCG(created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, game.ID)
+	assert.Contains(t, gcg, ">me: HUT 8G HUT +4 4\n")
+
+	imported, err := service.ImportGCG(gcg, "", "")
+	assert.NoError(t, err)
+	assert.NotEqual(t, created.ID, imported.ID, "Expected the import to be a new game")
+	assert.Equal(t, "testuser", imported.User)
+	assert.Equal(t, logic.DefaultLanguage, imported.Language)
+	assert.Equal(t, imported, model.GlobalPersistence.Games[imported.ID])
+	assert.Len(t, imported.PlayedMoves, 1)
+
+	_, err = service.ImportGCG("#player1 me\n", "", "")
+	assert.Error(t, err, "Expected error for a file without opponent")
+
+	_, _, err = service.ExportEndedGameGCG(created.ID)
+	assert.EqualError(t, err, "ended game not found")
+	_, err = service.EndGame(created.ID, "", false)
+	assert.NoError(t, err)
+	_, gcg, err = service.ExportEndedGameGCG(created.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, gcg, "8G HUT")
+}
+
+func TestRestoreBackupUnsupported(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	_, err := service.ListBackups()
+	assert.Error(t, err, "Expected error for a storage without backups")
+	assert.Error(t, service.RestoreBackup("games.json"))
+}
+
+func TestMatchWordToLetters(t *testing.T) {
+	tests := []struct {
+		word           string
+		letters        string
+		expectedBlanks []string
+		expectedOk     bool
+	}{
+		{"hello", "hleol", nil, true},                // No blank needed
+		{"hello", "helo*", []string{"l"}, true},      // Blank replaces the missing 'l'
+		{"hello", "h*e*o", []string{"l", "l"}, true}, // Two blanks for both 'l'
+		{"hello", "heo**", []string{"l", "l"}, true}, // Two blanks, both 'l' missing
+		{"hello", "he*o", nil, false},                // Not enough blanks
+		{"abc", "**c", []string{"a", "b"}, true},     // Blanks reported in word order
+		{"abc", "abc*", nil, true},                   // Unused blank
+		{"", "*", nil, true},                         // Empty word can always be built
+	}
+
+	for _, test := range tests {
+		t.Run(test.word+"_"+test.letters, func(t *testing.T) {
+			blanks, ok := matchWordToLetters(test.word, test.letters)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedBlanks, blanks)
+		})
+	}
+}
+
+func TestWordPoints(t *testing.T) {
+	values := logic.LetterValues(logic.LoadLettersPlaySet())
+
+	assert.Equal(t, uint(15), wordPoints("quiz", nil, values), "Expected full value without blanks")
+	assert.Equal(t, uint(5), wordPoints("quiz", []string{"q"}, values), "Expected blank to score zero")
+	assert.Equal(t, uint(5), wordPoints("hallo", []string{"l", "l"}, values), "Expected both blanks to score zero")
+}
+
+func TestFindWordsWithBlank(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"quiz": "", "quark": "", "zu": ""}}
+
+	words, err := service.FindWords("qui*", "", "")
+	assert.NoError(t, err)
+
+	assert.Len(t, words, 2, "Expected 'quiz' and 'zu' to be found")
+	assert.Equal(t, "quiz", words[0].Word)
+	assert.Equal(t, []string{"z"}, words[0].BlankLetters, "Expected blank to stand for 'z'")
+	assert.Equal(t, uint(12), words[0].Points, "Expected blank to score zero")
+	assert.Equal(t, "zu", words[1].Word)
+	assert.Equal(t, []string{"z"}, words[1].BlankLetters, "Expected blank to stand for 'z'")
+}
+
+func TestFindWordsWithUmlauts(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"übel": "", "Straße": "", "öl": "", "web-seite": ""}}
+
+	words, err := service.FindWords("übel", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "übel", words[0].Word, "Expected 'übel' to be found from the rack 'übel'")
+	assert.Equal(t, uint(11), words[0].Points)
+
+	words, err = service.FindWords("ÜBEL", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "übel", words[0].Word, "Expected rack to be lower cased")
+
+	words, err = service.FindWords("strasse", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "strasse", words[0].Word, "Expected 'ß' to be spelled as 'ss'")
+
+	words, err = service.FindWords("strasseölwb-i", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 2, "Expected hyphenated words to be skipped")
+	assert.Equal(t, "strasse", words[0].Word, "Expected words to be sorted by letter count")
+	assert.Equal(t, "öl", words[1].Word)
+
+	// the word list used to keep hyphenated words for racks with a hyphen
+	words, err = service.FindWords("web-seite", "", "")
+	assert.NoError(t, err)
+	assert.Empty(t, words, "Expected hyphenated words not to be found even with a hyphen in the rack")
+}
+
+func TestCreateGameWithLanguage(t *testing.T) {
+	service, mock := setupTestEnvironment()
+
+	game, err := service.CreateGame("testuser", "nl")
+	assert.NoError(t, err)
+
+	dutch, _ := logic.LoadLettersPlaySetFor("nl")
+	assert.Equal(t, "nl", game.Language, "Expected language to be stored")
+	assert.EqualValues(t, dutch, game.LettersPlaySet, "Expected Dutch tiles")
+
+	game, err = service.CreateGame("otheruser", "")
+	assert.NoError(t, err)
+	assert.Equal(t, logic.DefaultLanguage, model.GlobalPersistence.Games[game.ID].Language, "Expected default language")
+
+	mock.GameSaveCalled = false
+	_, err = service.CreateGame("newuser", "xx")
+	assert.Error(t, err, "Expected error for unsupported language")
+	assert.False(t, mock.GameSaveCalled, "SaveGamesToFile should not be called for unsupported language")
+	assert.Len(t, model.GlobalPersistence.Games, 2, "Game should not be created for unsupported language")
+
+	delete(model.GlobalDictionaries, "en")
+	model.GlobalMissingDictionaries = map[string]string{"en": "english_word_list.txt"}
+	_, err = service.CreateGame("newuser", "en")
+	assert.EqualError(t, err, `no word list is loaded for language "en", english_word_list.txt is missing`)
+	assert.Len(t, model.GlobalPersistence.Games, 2, "Game should not be created without a word list")
+
+	_, err = service.CreateGame("newuser", "fr")
+	assert.EqualError(t, err, `no word list is loaded for language "fr"`)
+	assert.Len(t, model.GlobalPersistence.Games, 2, "Game should not be created in a language without a configured word list")
+}
+
+func TestFindWordsWithLanguage(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{
+		"de": {"zoo": "", "zoom": ""},
+		"en": {"zoo": "", "öl": ""},
+	}
+
+	words, err := service.FindWords("zooöl", "en", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1, "Expected words with umlauts to be skipped")
+	assert.Equal(t, "zoo", words[0].Word)
+	assert.Equal(t, uint(12), words[0].Points, "Expected English letter values")
+
+	// Every language has its own word list
+	words, err = service.FindWords("zoom", "de", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 2, "Expected German word list to be used")
+	words, err = service.FindWords("zoom", "en", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1, "Expected English word list to be used")
+	words, err = service.FindWords("zoom", "nl", "")
+	assert.NoError(t, err)
+	assert.Empty(t, words, "Expected no words without a Dutch word list")
+
+	_, err = service.FindWords("zoo", "xx", "")
+	assert.Error(t, err, "Expected error for unsupported language")
+}
+
+func TestFindPatternWords(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"haus": "", "maus": "", "hausen": "", "häuser": ""}}
+
+	words, err := service.FindPatternWords(model.PatternQuery{Pattern: "_aus", Letters: "hm"})
+	assert.NoError(t, err)
+	assert.Len(t, words, 2)
+	assert.Equal(t, "haus", words[0].Word, "Expected words to be sorted")
+	assert.Equal(t, "maus", words[1].Word)
+
+	words, err = service.FindPatternWords(model.PatternQuery{Pattern: "h_us", Letters: "äer", Anchor: AnchorStart})
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "häuser", words[0].Word, "Expected umlauts in the rack to fill the pattern")
+
+	words, err = service.FindPatternWords(model.PatternQuery{Pattern: "aus", Letters: "hen", Anchor: AnchorNone, MaxLength: 4})
+	assert.NoError(t, err)
+	assert.Len(t, words, 1, "Expected length bounds to be applied")
+
+	invalidQueries := []model.PatternQuery{
+		{Pattern: "", Letters: "abc"},
+		{Pattern: "a-b", Letters: "abc"},
+		{Pattern: "abcdefghijklmnop", Letters: "abc"},
+		{Pattern: "_a", Letters: "abc", Anchor: "middle"},
+		{Pattern: "_a", Letters: "abc", MinLength: 5, MaxLength: 3},
+		{Pattern: "_a", Letters: "abc", Language: "xx"},
+	}
+	for _, query := range invalidQueries {
+		_, err := service.FindPatternWords(query)
+		assert.Error(t, err, "Expected error for query %+v", query)
+	}
+}
+
+func TestFindWordsWithCustomWords(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"ab": "", "ob": ""}}
+	model.GlobalPersistence.CustomWords = []model.CustomWord{
+		{Word: "bo", Category: "2 letters"},
+		{Word: "ob", Category: "2 letters"},
+		{Word: "boa", Category: "3 letters"},
+		{Word: "oa", Category: "2 letters", Language: "en"},
+	}
+
+	words, err := service.FindWords("abo", "", "")
+	assert.NoError(t, err)
+	sources := make(map[string]string)
+	for _, word := range words {
+		sources[word.Word] = word.Source
+	}
+	assert.Equal(t, map[string]string{
+		"ab":  model.SourceDWDS,
+		"ob":  model.SourceCustom,
+		"bo":  model.SourceCustom,
+		"boa": model.SourceCustom,
+	}, sources, "Expected custom words to be merged and flagged")
+
+	words, err = service.FindWords("abo", "", "3 letters")
+	assert.NoError(t, err)
+	sources = make(map[string]string)
+	for _, word := range words {
+		sources[word.Word] = word.Source
+	}
+	assert.Equal(t, map[string]string{
+		"ab":  model.SourceDWDS,
+		"ob":  model.SourceDWDS,
+		"boa": model.SourceCustom,
+	}, sources, "Expected custom words to be filtered by category")
+
+	words, err = service.FindWords("abo", "en", "")
+	assert.NoError(t, err)
+	assert.Len(t, words, 1, "Expected only English custom words")
+	assert.Equal(t, "oa", words[0].Word)
+}
+
+func TestGetPlayedWordsSources(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"hut": "", "tuh": ""}}
+	model.GlobalPersistence.CustomWords = []model.CustomWord{{Word: "uh"}}
+	model.GlobalPersistence.Games["user1"] = model.UserGame{
+		PlayedMoves: []model.PlayedMove{
+			{Words: []string{"Hut"}},
+			{Words: []string{"hut"}},
+		},
+	}
+
+	model.GlobalPersistence.Games["user2"] = model.UserGame{
+		Language:    "en",
+		PlayedMoves: []model.PlayedMove{{Words: []string{"hut", "uh"}}},
+	}
+
+	words, err := service.GetPlayedWords("hut", "")
+	assert.NoError(t, err)
+
+	assert.Len(t, words, 3)
+	found := make(map[string]model.WordCount)
+	for _, word := range words {
+		found[word.Word] = word
+	}
+	assert.Equal(t, model.SourcePlayed, found["hut"].Source)
+	assert.Equal(t, 2, found["hut"].CurrentCount, "Expected played words to be counted")
+	assert.Equal(t, model.SourceDWDS, found["tuh"].Source)
+	assert.Equal(t, model.SourceCustom, found["uh"].Source, "Expected words of English games not to be counted")
+
+	words, err = service.GetPlayedWords("hut", "en")
+	assert.NoError(t, err)
+	assert.Len(t, words, 2, "Expected only the words of English games")
+	for _, word := range words {
+		assert.Equal(t, model.SourcePlayed, word.Source)
+		assert.Equal(t, 1, word.CurrentCount)
+	}
+
+	_, err = service.GetPlayedWords("hut", "xx")
+	assert.Error(t, err, "Expected error for unsupported language")
+}
+
+func TestFindWordsWithInvalidWords(t *testing.T) {
+	service, _ := setupTestEnvironment()
+	model.GlobalDictionaries = map[string]model.WordMap{"de": {"ab": "", "ob": "", "boa": ""}}
+	model.GlobalPersistence.CustomWords = []model.CustomWord{
+		{Word: "ob", Category: model.CategoryInvalid},
+		{Word: "bo", Category: model.CategoryAllowed},
+		{Word: "ab", Category: model.CategoryInvalid, Language: "en"},
+	}
+
+	words, err := service.FindWords("abo", "", "")
+	assert.NoError(t, err)
+	sources := make(map[string]string)
+	for _, word := range words {
+		sources[word.Word] = word.Source
+	}
+	assert.Equal(t, map[string]string{
+		"ab":  model.SourceDWDS,
+		"bo":  model.SourceCustom,
+		"boa": model.SourceDWDS,
+	}, sources, "Expected invalid words to be removed and allowed words to be added")
+
+	words, err = service.FindWords("abo", "", model.CategoryInvalid)
+	assert.NoError(t, err)
+	for _, word := range words {
+		assert.NotEqual(t, "ob", word.Word, "Expected invalid words not to be added by their category")
+	}
+
+	words, err = service.FindPatternWords(model.PatternQuery{Pattern: "_b", Letters: "ao"})
+	assert.NoError(t, err)
+	assert.Len(t, words, 1)
+	assert.Equal(t, "ab", words[0].Word)
+}
+
+func TestImportCustomWords(t *testing.T) {
+	service, mock := setupTestEnvironment()
+	model.GlobalPersistence.CustomWords = []model.CustomWord{
+		{Word: "ob", Category: model.CategoryAllowed},
+		{Word: "bo", Category: model.CategoryInvalid},
+	}
+
+	result, err := service.ImportCustomWords(model.CustomWords{
+		Words:    []string{"Ob", "bo", " ab ", "", "ab"},
+		Category: model.CategoryInvalid,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, model.ImportResult{Imported: 1, Updated: 1, Skipped: 2}, result)
+	assert.True(t, mock.GameSaveCalled, "Expected the import to be saved")
+
+	invalid := service.GetCustomWordsByCategory(model.CategoryInvalid, "")
+	words := []string{}
+	for _, customWord := range invalid {
+		words = append(words, customWord.Word)
+	}
+	assert.Equal(t, []string{"ab", "bo", "ob"}, words)
+	assert.Empty(t, service.GetCustomWordsByCategory(model.CategoryAllowed, ""))
+	assert.Empty(t, service.GetCustomWordsByCategory(model.CategoryInvalid, "en"))
+
+	_, err = service.ImportCustomWords(model.CustomWords{Words: []string{"ab"}})
+	assert.Error(t, err, "Expected error without category")
+
+	_, err = service.ImportCustomWords(model.CustomWords{Words: []string{"ab"}, Category: model.CategoryInvalid, Language: "xx"})
+	assert.Error(t, err, "Expected error for unknown language")
+}
+
+func TestCustomWordsNormalized(t *testing.T) {
+	service, _ := setupTestEnvironment()
+
+	assert.NoError(t, service.AddCustomWords(model.CustomWords{Words: []string{" Straße "}, Category: "names"}))
+	assert.Equal(t, "strasse", model.GlobalPersistence.CustomWords[0].Word, "Expected added words to be normalized")
+
+	err := service.AddCustomWords(model.CustomWords{Words: []string{"STRASSE"}, Category: "names"})
+	assert.EqualError(t, err, "word 'strasse' already exists")
+	assert.NoError(t, service.AddCustomWords(model.CustomWords{Words: []string{"strasse"}, Category: "names", Language: "en"}), "Expected the word to be added in another language")
+
+	assert.EqualError(t, service.AddCustomWords(model.CustomWords{Words: []string{"ab"}}), "category is required")
+	assert.Error(t, service.AddCustomWords(model.CustomWords{Words: []string{"ab"}, Category: "names", Language: "xx"}), "Expected error for unknown language")
+
+	result, err := service.ImportCustomWords(model.CustomWords{Words: []string{"Straße"}, Category: "names"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Skipped, "Expected the imported spelling to match the added word")
+
+	// words stored before they were normalized are deleted as well
+	model.GlobalPersistence.CustomWords = append(model.GlobalPersistence.CustomWords, model.CustomWord{Word: "Übel"})
+	assert.NoError(t, service.DeleteCustomWord("übel", ""))
+	assert.NoError(t, service.DeleteCustomWord("Straße", "en"), "Expected the word of the language to be deleted")
+	assert.Len(t, model.GlobalPersistence.CustomWords, 1)
+	assert.Equal(t, "", model.GlobalPersistence.CustomWords[0].Language, "Expected the German word to be kept")
+	assert.EqualError(t, service.DeleteCustomWord("Straße", "en"), `word 'strasse' not found in language "en"`)
+	assert.Error(t, service.DeleteCustomWord("übel", "de"), "Expected error for a deleted word")
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"fmt"
+
+	"buchstaben.go/logic"
+	"buchstaben.go/model"
+)
+
+// OpponentStats sums up all active and ended games against the user.
+func (ds *DataService) OpponentStats(username string) (model.OpponentStats, error) {
+	model.GamesLock.Lock()
+	defer model.GamesLock.Unlock()
+
+	active := []model.UserGame{}
+	for _, game := range model.GlobalPersistence.Games {
+		if game.User == username {
+			active = append(active, game)
+		}
+	}
+	ended := []model.UserGame{}
+	for _, game := range model.GlobalPersistence.EndedGames {
+		if game.User == username {
+			ended = append(ended, game)
+		}
+	}
+	if len(active) == 0 && len(ended) == 0 {
+		return model.OpponentStats{}, fmt.Errorf("no games found against %s", username)
+	}
+	return logic.OpponentStatistics(username, active, ended), nil
+}